@@ -0,0 +1,141 @@
+package bimap
+
+import (
+	"fmt"
+
+	"github.com/BennettJames/ef"
+)
+
+type (
+	// BiMap is a one-to-one map, which can be looked up by either key or value.
+	// Every value belongs to exactly one key, so a value can only be added under
+	// a new key once its old mapping is removed.
+	//
+	// The zero value is an empty bimap ready to use.
+	BiMap[K, V comparable] struct {
+		forward map[K]V
+		inverse map[V]K
+	}
+
+	// ConflictError indicates that a value couldn't be added to a bimap under a
+	// key, as it already belongs to a different key.
+	ConflictError[K, V any] struct {
+		Key         K
+		Value       V
+		ExistingKey K
+	}
+)
+
+// New creates an empty bimap.
+func New[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// Put maps the key to the value, replacing any value the key had. If the value
+// already belongs to a different key, nothing is changed and a
+// `*ConflictError[K, V]` is returned.
+func (m *BiMap[K, V]) Put(key K, val V) error {
+	if existingKey, exists := m.inverse[val]; exists && existingKey != key {
+		return &ConflictError[K, V]{
+			Key:         key,
+			Value:       val,
+			ExistingKey: existingKey,
+		}
+	}
+	m.ForcePut(key, val)
+	return nil
+}
+
+// ForcePut maps the key to the value, removing any existing mappings for
+// either of them.
+func (m *BiMap[K, V]) ForcePut(key K, val V) {
+	m.RemoveByKey(key)
+	m.RemoveByValue(val)
+	if m.forward == nil {
+		m.forward = make(map[K]V)
+		m.inverse = make(map[V]K)
+	}
+	m.forward[key] = val
+	m.inverse[val] = key
+}
+
+// GetByKey returns the value for the key, or an empty optional if it has none.
+func (m *BiMap[K, V]) GetByKey(key K) ef.Opt[V] {
+	val, exists := m.forward[key]
+	if !exists {
+		return ef.Opt[V]{}
+	}
+	return ef.NewOptValue(val)
+}
+
+// GetByValue returns the key for the value, or an empty optional if it has
+// none.
+func (m *BiMap[K, V]) GetByValue(val V) ef.Opt[K] {
+	key, exists := m.inverse[val]
+	if !exists {
+		return ef.Opt[K]{}
+	}
+	return ef.NewOptValue(key)
+}
+
+// RemoveByKey removes the key and its value, and returns the value if there
+// was one.
+func (m *BiMap[K, V]) RemoveByKey(key K) ef.Opt[V] {
+	val, exists := m.forward[key]
+	if !exists {
+		return ef.Opt[V]{}
+	}
+	delete(m.forward, key)
+	delete(m.inverse, val)
+	return ef.NewOptValue(val)
+}
+
+// RemoveByValue removes the value and its key, and returns the key if there
+// was one.
+func (m *BiMap[K, V]) RemoveByValue(val V) ef.Opt[K] {
+	key, exists := m.inverse[val]
+	if !exists {
+		return ef.Opt[K]{}
+	}
+	delete(m.forward, key)
+	delete(m.inverse, val)
+	return ef.NewOptValue(key)
+}
+
+// Len returns the number of mappings in the bimap.
+func (m *BiMap[K, V]) Len() int {
+	return len(m.forward)
+}
+
+// Inverse returns a copy of the bimap with keys and values swapped.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	inv := New[V, K]()
+	for k, v := range m.forward {
+		inv.forward[v] = k
+		inv.inverse[k] = v
+	}
+	return inv
+}
+
+// Stream returns a stream of the key/value pairs in the bimap, in random
+// order.
+func (m *BiMap[K, V]) Stream() ef.Stream[ef.Pair[K, V]] {
+	return ef.NewStream[ef.Pair[K, V]](&ef.FnIter[ef.Pair[K, V]]{
+		Fn: func(opFn func(ef.Pair[K, V]) bool) {
+			for k, v := range m.forward {
+				if !opFn(ef.PairOf(k, v)) {
+					return
+				}
+			}
+		},
+	})
+}
+
+func (e *ConflictError[K, V]) Error() string {
+	return fmt.Sprintf(
+		"value '%v' for key '%v' already belongs to key '%v'",
+		e.Value, e.Key, e.ExistingKey)
+}
@@ -0,0 +1,104 @@
+package bimap
+
+import (
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMapPut(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		m := New[string, int]()
+		assert.NoError(t, m.Put("a", 1))
+		assert.NoError(t, m.Put("b", 2))
+		assert.Equal(t, ef.NewOptValue(1), m.GetByKey("a"))
+		assert.Equal(t, ef.NewOptValue("b"), m.GetByValue(2))
+		assert.Equal(t, ef.Opt[int]{}, m.GetByKey("c"))
+		assert.Equal(t, ef.Opt[string]{}, m.GetByValue(3))
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("ReplaceValue", func(t *testing.T) {
+		m := New[string, int]()
+		assert.NoError(t, m.Put("a", 1))
+		assert.NoError(t, m.Put("a", 2))
+		assert.Equal(t, ef.NewOptValue(2), m.GetByKey("a"))
+		assert.Equal(t, ef.Opt[string]{}, m.GetByValue(1))
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("Same", func(t *testing.T) {
+		m := New[string, int]()
+		assert.NoError(t, m.Put("a", 1))
+		assert.NoError(t, m.Put("a", 1))
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		m := New[string, int]()
+		assert.NoError(t, m.Put("a", 1))
+		err := m.Put("b", 1)
+		assert.Equal(t, &ConflictError[string, int]{
+			Key:         "b",
+			Value:       1,
+			ExistingKey: "a",
+		}, err)
+		assert.EqualError(t, err, "value '1' for key 'b' already belongs to key 'a'")
+		assert.Equal(t, ef.NewOptValue("a"), m.GetByValue(1))
+		assert.Equal(t, ef.Opt[int]{}, m.GetByKey("b"))
+	})
+}
+
+func TestBiMapZero(t *testing.T) {
+	var m BiMap[string, int]
+	assert.Equal(t, ef.Opt[int]{}, m.GetByKey("a"))
+	assert.Equal(t, ef.Opt[string]{}, m.RemoveByValue(1))
+	assert.NoError(t, m.Put("a", 1))
+	assert.Equal(t, ef.NewOptValue("a"), m.GetByValue(1))
+	assert.Equal(t, 1, m.Inverse().Len())
+}
+
+func TestBiMapForcePut(t *testing.T) {
+	m := New[string, int]()
+	m.ForcePut("a", 1)
+	m.ForcePut("b", 2)
+	m.ForcePut("a", 2)
+	assert.Equal(t, ef.NewOptValue(2), m.GetByKey("a"))
+	assert.Equal(t, ef.NewOptValue("a"), m.GetByValue(2))
+	assert.Equal(t, ef.Opt[int]{}, m.GetByKey("b"))
+	assert.Equal(t, ef.Opt[string]{}, m.GetByValue(1))
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestBiMapRemove(t *testing.T) {
+	m := New[string, int]()
+	m.ForcePut("a", 1)
+	m.ForcePut("b", 2)
+
+	assert.Equal(t, ef.NewOptValue(1), m.RemoveByKey("a"))
+	assert.Equal(t, ef.Opt[int]{}, m.RemoveByKey("a"))
+	assert.Equal(t, ef.Opt[string]{}, m.GetByValue(1))
+
+	assert.Equal(t, ef.NewOptValue("b"), m.RemoveByValue(2))
+	assert.Equal(t, ef.Opt[string]{}, m.RemoveByValue(2))
+	assert.Equal(t, ef.Opt[int]{}, m.GetByKey("b"))
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestBiMapInverse(t *testing.T) {
+	m := New[string, int]()
+	m.ForcePut("a", 1)
+	inv := m.Inverse()
+	assert.Equal(t, ef.NewOptValue("a"), inv.GetByKey(1))
+	assert.Equal(t, ef.NewOptValue(1), inv.GetByValue("a"))
+
+	inv.ForcePut(2, "b")
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestBiMapStream(t *testing.T) {
+	m := New[string, int]()
+	m.ForcePut("a", 1)
+	assert.Equal(t, ef.Slice(ef.PairOf("a", 1)), m.Stream().ToSlice())
+}
@@ -0,0 +1,380 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/BennettJames/ef"
+)
+
+type (
+	// Cache is a size-bounded, least-recently-used cache, with optional expiry
+	// of entries. It is safe for concurrent use.
+	Cache[K comparable, V any] struct {
+		cfg Config[K, V]
+
+		mu         sync.Mutex
+		entries    map[K]*entry[K, V]
+		head, tail *entry[K, V]
+		calls      map[K]*call[V]
+		stats      Stats
+	}
+
+	// Config sets up a cache. Only MaxSize is required.
+	Config[K comparable, V any] struct {
+		// MaxSize is the most entries the cache will hold. Once it is full,
+		// adding an entry evicts the least recently used one. Must be positive.
+		MaxSize int
+
+		// TTL is how long an entry lasts after it is added. If zero, entries never
+		// expire.
+		TTL time.Duration
+
+		// Clock returns the current time, and is used to expire entries. Defaults
+		// to time.Now; tests can substitute a fake clock.
+		Clock func() time.Time
+
+		// OnEvict is called whenever an entry leaves the cache for any reason
+		// other than being replaced. It is called after the cache's lock is
+		// released, so can safely use the cache.
+		OnEvict func(key K, val V, reason EvictReason)
+	}
+
+	// EvictReason describes why an entry left the cache.
+	EvictReason int
+
+	// Stats counts how the cache has been used. Hits and misses are counted for
+	// each lookup, and evictions for each entry that was pushed out or expired.
+	// Entries that are explicitly removed are not counted as evictions.
+	Stats struct {
+		Hits, Misses, Evictions uint64
+	}
+
+	entry[K comparable, V any] struct {
+		key        K
+		val        V
+		expires    time.Time
+		prev, next *entry[K, V]
+	}
+
+	// call tracks a computation in progress in GetOrCompute, so that concurrent
+	// requests for the same key can wait on it rather than repeat it.
+	call[V any] struct {
+		done chan struct{}
+		res  ef.Res[V]
+
+		// overwritten is set if the key is put or removed while the computation
+		// is running, in which case its result is out of date and isn't stored.
+		overwritten bool
+	}
+
+	// AbortedError is the result shared with goroutines waiting on a
+	// GetOrCompute call whose compute function exited without returning - e.g.
+	// by calling runtime.Goexit.
+	AbortedError struct{}
+
+	eviction[K comparable, V any] struct {
+		key    K
+		val    V
+		reason EvictReason
+	}
+)
+
+const (
+	// EvictCapacity means the entry was the least recently used when the cache
+	// needed room for another.
+	EvictCapacity EvictReason = iota
+
+	// EvictExpired means the entry's TTL had passed.
+	EvictExpired
+
+	// EvictRemoved means the entry was explicitly removed.
+	EvictRemoved
+)
+
+// New creates an empty cache with the given config.
+func New[K comparable, V any](cfg Config[K, V]) *Cache[K, V] {
+	ef.AssertMsgf(cfg.MaxSize > 0, "cache max size must be positive - got %d", cfg.MaxSize)
+	if cfg.Clock == nil {
+		cfg.Clock = time.Now
+	}
+	return &Cache[K, V]{
+		cfg:     cfg,
+		entries: make(map[K]*entry[K, V]),
+		calls:   make(map[K]*call[V]),
+	}
+}
+
+// Get returns the value for the key, or an empty optional if it is not cached
+// or has expired. A found value becomes the most recently used.
+func (c *Cache[K, V]) Get(key K) ef.Opt[V] {
+	c.mu.Lock()
+	val, found, evicted := c.lookup(key)
+	c.mu.Unlock()
+	c.notify(evicted)
+	if !found {
+		return ef.Opt[V]{}
+	}
+	return ef.NewOptValue(val)
+}
+
+// Put adds the value to the cache as the most recently used, replacing any
+// existing value for the key.
+func (c *Cache[K, V]) Put(key K, val V) {
+	c.mu.Lock()
+	c.markOverwritten(key)
+	evicted := c.store(key, val)
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Remove takes the key out of the cache, and indicates if it was there.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	c.markOverwritten(key)
+	e, exists := c.entries[key]
+	var evicted []eviction[K, V]
+	if exists {
+		evicted = append(evicted, c.evict(e, EvictRemoved))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+	return exists
+}
+
+// GetOrCompute returns the value for the key if it is cached; otherwise it
+// calls `computeFn` to get it, and caches it if it is a value rather than an
+// error. If the key is put or removed while `computeFn` runs, the computed
+// value is still returned but isn't cached, so it can't overwrite the newer
+// write.
+//
+// If several goroutines ask for the same missing key at once, only one of them
+// calls `computeFn`, and the rest wait for and share its result. If `computeFn`
+// panics, the panic continues in its own goroutine and the waiters get an
+// error result.
+func (c *Cache[K, V]) GetOrCompute(key K, computeFn func() ef.Res[V]) ef.Res[V] {
+	c.mu.Lock()
+	val, found, evicted := c.lookup(key)
+	if found {
+		c.mu.Unlock()
+		c.notify(evicted)
+		return ef.NewResValue(val)
+	}
+	if cl, inProgress := c.calls[key]; inProgress {
+		c.mu.Unlock()
+		c.notify(evicted)
+		<-cl.done
+		return cl.res
+	}
+	cl := &call[V]{done: make(chan struct{})}
+	c.calls[key] = cl
+	c.mu.Unlock()
+	c.notify(evicted)
+
+	returned := false
+	defer func() {
+		// this always runs - even on a panic or runtime.Goexit - so waiters are
+		// never left blocked.
+		var r any
+		if !returned {
+			r = recover()
+			if r != nil {
+				cl.res = ef.NewResError[V](ef.NewRecoverError(r))
+			} else {
+				cl.res = ef.NewResError[V](&AbortedError{})
+			}
+		}
+		c.finishCall(key, cl)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	cl.res = computeFn()
+	returned = true
+	return cl.res
+}
+
+// Len returns the number of entries in the cache. This can include expired
+// entries that haven't been removed yet; see RemoveExpired.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// RemoveExpired removes every entry whose TTL has passed. Expired entries are
+// never returned either way, but this frees them without waiting for them to
+// be looked up or pushed out.
+func (c *Cache[K, V]) RemoveExpired() {
+	c.mu.Lock()
+	var evicted []eviction[K, V]
+	now := c.cfg.Clock()
+	for e := c.head; e != nil; {
+		next := e.next
+		if c.expired(e, now) {
+			evicted = append(evicted, c.evict(e, EvictExpired))
+		}
+		e = next
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Stats returns the cache's usage counts so far.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// HitRate returns the fraction of lookups that found a value, or 0 if there
+// have been none.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+func (e *AbortedError) Error() string {
+	return "cache computation exited without returning a result"
+}
+
+func (c *Cache[K, V]) finishCall(key K, cl *call[V]) {
+	c.mu.Lock()
+	var evicted []eviction[K, V]
+	if cl.res.IsVal() && !cl.overwritten {
+		evicted = c.store(key, cl.res.Val())
+	}
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(cl.done)
+	c.notify(evicted)
+}
+
+// markOverwritten flags any computation in progress for the key as out of
+// date. Must be called with the lock held.
+func (c *Cache[K, V]) markOverwritten(key K) {
+	if cl, inProgress := c.calls[key]; inProgress {
+		cl.overwritten = true
+	}
+}
+
+// lookup finds the unexpired value for the key, and updates the stats and LRU
+// order accordingly. Must be called with the lock held.
+func (c *Cache[K, V]) lookup(key K) (val V, found bool, evicted []eviction[K, V]) {
+	e, exists := c.entries[key]
+	if exists && c.expired(e, c.cfg.Clock()) {
+		evicted = append(evicted, c.evict(e, EvictExpired))
+		exists = false
+	}
+	if !exists {
+		c.stats.Misses++
+		return val, false, evicted
+	}
+	c.stats.Hits++
+	c.moveToFront(e)
+	return e.val, true, evicted
+}
+
+// store sets the value for the key as the most recently used, evicting the
+// least recently used entry if the cache is over size. Must be called with the
+// lock held.
+func (c *Cache[K, V]) store(key K, val V) []eviction[K, V] {
+	var expires time.Time
+	if c.cfg.TTL > 0 {
+		expires = c.cfg.Clock().Add(c.cfg.TTL)
+	}
+	if e, exists := c.entries[key]; exists {
+		e.val = val
+		e.expires = expires
+		c.moveToFront(e)
+		return nil
+	}
+	e := &entry[K, V]{
+		key:     key,
+		val:     val,
+		expires: expires,
+	}
+	c.entries[key] = e
+	c.pushFront(e)
+
+	var evicted []eviction[K, V]
+	if len(c.entries) > c.cfg.MaxSize {
+		evicted = append(evicted, c.evict(c.tail, EvictCapacity))
+	}
+	return evicted
+}
+
+func (c *Cache[K, V]) expired(e *entry[K, V], now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+func (c *Cache[K, V]) evict(e *entry[K, V], reason EvictReason) eviction[K, V] {
+	c.unlink(e)
+	delete(c.entries, e.key)
+	if reason != EvictRemoved {
+		c.stats.Evictions++
+	}
+	return eviction[K, V]{
+		key:    e.key,
+		val:    e.val,
+		reason: reason,
+	}
+}
+
+func (c *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	if c.cfg.OnEvict == nil {
+		return
+	}
+	for _, ev := range evicted {
+		c.cfg.OnEvict(ev.key, ev.val, ev.reason)
+	}
+}
+
+func (c *Cache[K, V]) moveToFront(e *entry[K, V]) {
+	if c.head == e {
+		return
+	}
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+func (c *Cache[K, V]) pushFront(e *entry[K, V]) {
+	e.prev = nil
+	e.next = c.head
+	if c.head != nil {
+		c.head.prev = e
+	} else {
+		c.tail = e
+	}
+	c.head = e
+}
+
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		c.tail = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
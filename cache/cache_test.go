@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestCacheGetPut(t *testing.T) {
+	c := New(Config[string, int]{MaxSize: 2})
+	assert.Equal(t, ef.Opt[int]{}, c.Get("a"))
+	c.Put("a", 1)
+	c.Put("b", 2)
+	assert.Equal(t, ef.NewOptValue(1), c.Get("a"))
+	c.Put("b", 3)
+	assert.Equal(t, ef.NewOptValue(3), c.Get("b"))
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, Stats{Hits: 2, Misses: 1}, c.Stats())
+}
+
+func TestCacheLRU(t *testing.T) {
+	type evicted struct {
+		key    string
+		val    int
+		reason EvictReason
+	}
+	var evictions []evicted
+	c := New(Config[string, int]{
+		MaxSize: 2,
+		OnEvict: func(key string, val int, reason EvictReason) {
+			evictions = append(evictions, evicted{key, val, reason})
+		},
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Put("c", 3)
+	assert.Equal(t, ef.Opt[int]{}, c.Get("b"))
+	assert.Equal(t, ef.NewOptValue(1), c.Get("a"))
+	assert.Equal(t, ef.NewOptValue(3), c.Get("c"))
+	assert.Equal(t, []evicted{{"b", 2, EvictCapacity}}, evictions)
+
+	assert.True(t, c.Remove("a"))
+	assert.False(t, c.Remove("a"))
+	assert.Equal(t, evicted{"a", 1, EvictRemoved}, evictions[1])
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+}
+
+func TestCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	var reasons []EvictReason
+	c := New(Config[string, int]{
+		MaxSize: 10,
+		TTL:     time.Minute,
+		Clock:   clock.Now,
+		OnEvict: func(key string, val int, reason EvictReason) {
+			reasons = append(reasons, reason)
+		},
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		c.Put("a", 1)
+		clock.Advance(59 * time.Second)
+		assert.Equal(t, ef.NewOptValue(1), c.Get("a"))
+		clock.Advance(time.Second)
+		assert.Equal(t, ef.Opt[int]{}, c.Get("a"))
+		assert.Equal(t, 0, c.Len())
+		assert.Equal(t, []EvictReason{EvictExpired}, reasons)
+	})
+
+	t.Run("PutRefreshes", func(t *testing.T) {
+		c.Put("a", 1)
+		clock.Advance(30 * time.Second)
+		c.Put("a", 2)
+		clock.Advance(45 * time.Second)
+		assert.Equal(t, ef.NewOptValue(2), c.Get("a"))
+	})
+
+	t.Run("RemoveExpired", func(t *testing.T) {
+		c.Put("b", 1)
+		c.Put("c", 2)
+		clock.Advance(time.Minute)
+		c.Put("d", 3)
+		c.RemoveExpired()
+		assert.Equal(t, 1, c.Len())
+		assert.Equal(t, ef.NewOptValue(3), c.Get("d"))
+	})
+}
+
+func TestCacheGetOrCompute(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		calls := 0
+		compute := func() ef.Res[int] {
+			calls++
+			return ef.NewResValue(5)
+		}
+		assert.Equal(t, ef.NewResValue(5), c.GetOrCompute("a", compute))
+		assert.Equal(t, ef.NewResValue(5), c.GetOrCompute("a", compute))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		err := errors.New("failed")
+		res := c.GetOrCompute("a", func() ef.Res[int] {
+			return ef.NewResError[int](err)
+		})
+		assert.Equal(t, err, res.Err())
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		var calls atomic.Int32
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		results := make([]ef.Res[int], 10)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = c.GetOrCompute("a", func() ef.Res[int] {
+					calls.Add(1)
+					<-release
+					return ef.NewResValue(7)
+				})
+			}()
+		}
+		// give every goroutine a chance to start waiting before finishing.
+		for c.Stats().Misses < uint64(len(results)) {
+			time.Sleep(time.Millisecond)
+		}
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), calls.Load())
+		for _, res := range results {
+			assert.Equal(t, ef.NewResValue(7), res)
+		}
+	})
+
+	t.Run("Goexit", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		started, release := make(chan struct{}), make(chan struct{})
+		go func() {
+			c.GetOrCompute("a", func() ef.Res[int] {
+				close(started)
+				<-release
+				runtime.Goexit()
+				return ef.NewResValue(1)
+			})
+		}()
+		<-started
+
+		waiterRes := make(chan ef.Res[int])
+		go func() {
+			waiterRes <- c.GetOrCompute("a", func() ef.Res[int] {
+				return ef.NewResValue(2)
+			})
+		}()
+		for c.Stats().Misses < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		close(release)
+
+		select {
+		case res := <-waiterRes:
+			assert.Equal(t, &AbortedError{}, res.Err())
+		case <-time.After(5 * time.Second):
+			t.Fatal("waiter was never released")
+		}
+		assert.Equal(t, ef.NewResValue(3), c.GetOrCompute("a", func() ef.Res[int] {
+			return ef.NewResValue(3)
+		}))
+	})
+
+	t.Run("PutDuringCompute", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		res := c.GetOrCompute("a", func() ef.Res[int] {
+			c.Put("a", 2)
+			return ef.NewResValue(1)
+		})
+		assert.Equal(t, ef.NewResValue(1), res)
+		assert.Equal(t, ef.NewOptValue(2), c.Get("a"))
+	})
+
+	t.Run("RemoveDuringCompute", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		res := c.GetOrCompute("a", func() ef.Res[int] {
+			c.Remove("a")
+			return ef.NewResValue(1)
+		})
+		assert.Equal(t, ef.NewResValue(1), res)
+		assert.Equal(t, ef.Opt[int]{}, c.Get("a"))
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		c := New(Config[string, int]{MaxSize: 2})
+		assert.Panics(t, func() {
+			c.GetOrCompute("a", func() ef.Res[int] { panic("failed") })
+		})
+		assert.Equal(t, ef.NewResValue(1), c.GetOrCompute("a", func() ef.Res[int] {
+			return ef.NewResValue(1)
+		}))
+	})
+}
+
+func TestCacheStats(t *testing.T) {
+	assert.Equal(t, 0.0, Stats{}.HitRate())
+	assert.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRate())
+}
+
+func TestCacheNew(t *testing.T) {
+	assert.Panics(t, func() { New(Config[string, int]{}) })
+}
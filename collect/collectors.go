@@ -0,0 +1,275 @@
+package collect
+
+import (
+	"strings"
+
+	"github.com/BennettJames/ef"
+)
+
+// ToSlice returns a collector that gathers every value into a slice.
+func ToSlice[T any]() ef.Collector[T, []T, []T] {
+	return ef.Collector[T, []T, []T]{
+		Supply: func() []T {
+			return make([]T, 0)
+		},
+		Accumulate: func(acc []T, val T) []T {
+			return append(acc, val)
+		},
+		Finish: identity[[]T],
+	}
+}
+
+// ToMap returns a collector that gathers pairs into a map, where the keys are
+// the first value in the pairs, and the values the second.
+//
+// Note that this cannot handle key collisions - if two pairs have the same key,
+// this will panic with a `*ef.DuplicateKeyError`. Use `ToMapMerge` to resolve
+// collisions.
+func ToMap[K comparable, V any]() ef.Collector[ef.Pair[K, V], map[K]V, map[K]V] {
+	return ef.Collector[ef.Pair[K, V], map[K]V, map[K]V]{
+		Supply: func() map[K]V {
+			return make(map[K]V)
+		},
+		Accumulate: func(acc map[K]V, p ef.Pair[K, V]) map[K]V {
+			if existing, exists := acc[p.First]; exists {
+				panic(&ef.DuplicateKeyError[K, V]{
+					Key:       p.First,
+					Existing:  existing,
+					Duplicate: p.Second,
+				})
+			}
+			acc[p.First] = p.Second
+			return acc
+		},
+		Finish: identity[map[K]V],
+	}
+}
+
+// ToMapMerge returns a collector that gathers pairs into a map like ToMap, but
+// resolves any duplicate keys by combining the values with `mergeOp`.
+func ToMapMerge[K comparable, V any](
+	mergeOp func(key K, existing, val V) V,
+) ef.Collector[ef.Pair[K, V], map[K]V, map[K]V] {
+	return ef.Collector[ef.Pair[K, V], map[K]V, map[K]V]{
+		Supply: func() map[K]V {
+			return make(map[K]V)
+		},
+		Accumulate: func(acc map[K]V, p ef.Pair[K, V]) map[K]V {
+			if existing, exists := acc[p.First]; exists {
+				acc[p.First] = mergeOp(p.First, existing, p.Second)
+			} else {
+				acc[p.First] = p.Second
+			}
+			return acc
+		},
+		Finish: identity[map[K]V],
+	}
+}
+
+// Reducing returns a collector that combines every value down to one, starting
+// with `initVal` and calling `reduceOp` with the running total and each value.
+//
+// The same `initVal` is used every time the collector starts over - e.g. for
+// each group under GroupingBy - so it must be a value type. For a map, pointer
+// or other reference type, use ReducingSupply so each gets its own.
+func Reducing[T, U any](initVal U, reduceOp func(total U, val T) U) ef.Collector[T, U, U] {
+	return ReducingSupply(func() U { return initVal }, reduceOp)
+}
+
+// ReducingSupply is as Reducing, but calls `supplyOp` for a fresh starting
+// value each time the collector starts over.
+func ReducingSupply[T, U any](supplyOp func() U, reduceOp func(total U, val T) U) ef.Collector[T, U, U] {
+	return ef.Collector[T, U, U]{
+		Supply:     supplyOp,
+		Accumulate: reduceOp,
+		Finish:     identity[U],
+	}
+}
+
+// Counting returns a collector that counts the number of values.
+func Counting[T any]() ef.Collector[T, int, int] {
+	return Reducing(0, func(count int, _ T) int {
+		return count + 1
+	})
+}
+
+// Summing returns a collector that adds up all the values.
+//
+// Note that this is not safe with overflow - if the sum exceeds the number
+// type, then overflow will occur.
+func Summing[N ef.Number]() ef.Collector[N, N, N] {
+	return Reducing(0, ef.Add[N])
+}
+
+// Averaging returns a collector that calculates the mean of all the values. The
+// average of no values is 0.
+func Averaging[N ef.Number]() ef.Collector[N, ef.Pair[float64, int], float64] {
+	return ef.Collector[N, ef.Pair[float64, int], float64]{
+		Supply: func() ef.Pair[float64, int] {
+			return ef.Pair[float64, int]{}
+		},
+		Accumulate: func(acc ef.Pair[float64, int], val N) ef.Pair[float64, int] {
+			return ef.PairOf(acc.First+float64(val), acc.Second+1)
+		},
+		Finish: func(acc ef.Pair[float64, int]) float64 {
+			if acc.Second == 0 {
+				return 0
+			}
+			return acc.First / float64(acc.Second)
+		},
+	}
+}
+
+// Stats returns a collector that calculates the SummaryStats object for a set
+// of numbers.
+func Stats[N ef.Number]() ef.Collector[N, ef.SummaryStats[N], ef.SummaryStats[N]] {
+	return Reducing(ef.SummaryStats[N]{}, ef.SummaryStats[N].Add)
+}
+
+// Joining returns a collector that combines strings into a single string,
+// adding `sep` between each one.
+func Joining(sep string) ef.Collector[string, ef.Pair[*strings.Builder, bool], string] {
+	return ef.Collector[string, ef.Pair[*strings.Builder, bool], string]{
+		Supply: func() ef.Pair[*strings.Builder, bool] {
+			return ef.PairOf(&strings.Builder{}, true)
+		},
+		Accumulate: func(acc ef.Pair[*strings.Builder, bool], v string) ef.Pair[*strings.Builder, bool] {
+			sb, first := acc.Get()
+			if !first {
+				sb.WriteString(sep)
+			}
+			sb.WriteString(v)
+			return ef.PairOf(sb, false)
+		},
+		Finish: func(acc ef.Pair[*strings.Builder, bool]) string {
+			return acc.First.String()
+		},
+	}
+}
+
+// Mapping adapts a collector to accept values of a different type, by
+// transforming each value with `mapOp` before passing it downstream.
+//
+// Example:
+//
+//	lengths := Mapping(func(s string) int { return len(s) }, Summing[int]())
+func Mapping[T, U, A, R any](
+	mapOp func(T) U,
+	downstream ef.Collector[U, A, R],
+) ef.Collector[T, A, R] {
+	return ef.Collector[T, A, R]{
+		Supply: downstream.Supply,
+		Accumulate: func(acc A, val T) A {
+			return downstream.Accumulate(acc, mapOp(val))
+		},
+		Finish: downstream.Finish,
+	}
+}
+
+// Filtering adapts a collector to only receive the values that pass the given
+// check.
+func Filtering[T, A, R any](
+	keepOp func(T) bool,
+	downstream ef.Collector[T, A, R],
+) ef.Collector[T, A, R] {
+	return ef.Collector[T, A, R]{
+		Supply: downstream.Supply,
+		Accumulate: func(acc A, val T) A {
+			if keepOp(val) {
+				return downstream.Accumulate(acc, val)
+			}
+			return acc
+		},
+		Finish: downstream.Finish,
+	}
+}
+
+// Teeing passes every value to two collectors, and combines their results with
+// `mergeOp`.
+//
+// Example:
+//
+//	avg := Teeing(Summing[int](), Counting[int](), func(sum, count int) float64 {
+//	  return float64(sum) / float64(count)
+//	})
+func Teeing[T, A1, R1, A2, R2, R any](
+	c1 ef.Collector[T, A1, R1],
+	c2 ef.Collector[T, A2, R2],
+	mergeOp func(R1, R2) R,
+) ef.Collector[T, ef.Pair[A1, A2], R] {
+	return ef.Collector[T, ef.Pair[A1, A2], R]{
+		Supply: func() ef.Pair[A1, A2] {
+			return ef.PairOf(c1.Supply(), c2.Supply())
+		},
+		Accumulate: func(acc ef.Pair[A1, A2], val T) ef.Pair[A1, A2] {
+			return ef.PairOf(c1.Accumulate(acc.First, val), c2.Accumulate(acc.Second, val))
+		},
+		Finish: func(acc ef.Pair[A1, A2]) R {
+			return mergeOp(c1.Finish(acc.First), c2.Finish(acc.Second))
+		},
+	}
+}
+
+// GroupingBy returns a collector that groups values by the key returned from
+// `keyOp`, and gathers the values of each group with the downstream collector.
+//
+// Example:
+//
+//	avgSalaryByDept := GroupingBy(
+//	  func(e Employee) string { return e.Dept },
+//	  Mapping(func(e Employee) int { return e.Salary }, Averaging[int]()))
+func GroupingBy[T any, K comparable, A, R any](
+	keyOp func(T) K,
+	downstream ef.Collector[T, A, R],
+) ef.Collector[T, map[K]A, map[K]R] {
+	return ef.Collector[T, map[K]A, map[K]R]{
+		Supply: func() map[K]A {
+			return make(map[K]A)
+		},
+		Accumulate: func(acc map[K]A, val T) map[K]A {
+			key := keyOp(val)
+			groupAcc, exists := acc[key]
+			if !exists {
+				groupAcc = downstream.Supply()
+			}
+			acc[key] = downstream.Accumulate(groupAcc, val)
+			return acc
+		},
+		Finish: func(acc map[K]A) map[K]R {
+			groups := make(map[K]R, len(acc))
+			for key, groupAcc := range acc {
+				groups[key] = downstream.Finish(groupAcc)
+			}
+			return groups
+		},
+	}
+}
+
+// Partitioning returns a collector that splits values in two based on the
+// given check. The first value of the resulting pair is gathered from the
+// values that pass the check, and the second from those that don't.
+func Partitioning[T, A, R any](
+	checkOp func(T) bool,
+	downstream ef.Collector[T, A, R],
+) ef.Collector[T, ef.Pair[A, A], ef.Pair[R, R]] {
+	return ef.Collector[T, ef.Pair[A, A], ef.Pair[R, R]]{
+		Supply: func() ef.Pair[A, A] {
+			return ef.PairOf(downstream.Supply(), downstream.Supply())
+		},
+		Accumulate: func(acc ef.Pair[A, A], val T) ef.Pair[A, A] {
+			if checkOp(val) {
+				acc.First = downstream.Accumulate(acc.First, val)
+			} else {
+				acc.Second = downstream.Accumulate(acc.Second, val)
+			}
+			return acc
+		},
+		Finish: func(acc ef.Pair[A, A]) ef.Pair[R, R] {
+			return ef.PairOf(downstream.Finish(acc.First), downstream.Finish(acc.Second))
+		},
+	}
+}
+
+func identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,173 @@
+package collect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSlice(t *testing.T) {
+	assert.Equal(t, ef.Slice(1, 2, 3), collectVals(ToSlice[int](), 1, 2, 3))
+	assert.Equal(t, ef.Slice[int](), collectVals(ToSlice[int]()))
+}
+
+func TestToMap(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]int{"a": 1, "b": 2},
+			collectVals(ToMap[string, int](), ef.PairOf("a", 1), ef.PairOf("b", 2)))
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		assert.PanicsWithError(t,
+			"duplicate values found for key 'a' - ['1', '2']",
+			func() {
+				collectVals(ToMap[string, int](), ef.PairOf("a", 1), ef.PairOf("a", 2))
+			})
+	})
+}
+
+func TestToMapMerge(t *testing.T) {
+	sum := ToMapMerge(func(key string, v1, v2 int) int { return v1 + v2 })
+	assert.Equal(t,
+		map[string]int{"a": 4, "b": 2},
+		collectVals(sum, ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 3)))
+}
+
+func TestReducing(t *testing.T) {
+	assert.Equal(t, 24, collectVals(Reducing(1, ef.Mult[int]), 1, 2, 3, 4))
+}
+
+func TestReducingSupply(t *testing.T) {
+	countWords := ReducingSupply(
+		func() map[string]int { return make(map[string]int) },
+		func(counts map[string]int, word string) map[string]int {
+			counts[word]++
+			return counts
+		})
+	byLen := GroupingBy(func(s string) int { return len(s) }, countWords)
+	assert.Equal(t,
+		map[int]map[string]int{
+			1: {"a": 2},
+			2: {"bb": 1},
+		},
+		collectVals(byLen, "a", "bb", "a"))
+}
+
+func TestCounting(t *testing.T) {
+	assert.Equal(t, 3, collectVals(Counting[string](), "a", "b", "c"))
+	assert.Equal(t, 0, collectVals(Counting[string]()))
+}
+
+func TestSumming(t *testing.T) {
+	assert.Equal(t, 6, collectVals(Summing[int](), 1, 2, 3))
+	assert.Equal(t, 1.5, collectVals(Summing[float64](), 1.0, 0.5))
+}
+
+func TestAveraging(t *testing.T) {
+	assert.Equal(t, 2.5, collectVals(Averaging[int](), 1, 2, 3, 4))
+	assert.Equal(t, 0.0, collectVals(Averaging[int]()))
+}
+
+func TestStats(t *testing.T) {
+	assert.Equal(t,
+		ef.SummaryStats[int]{
+			Average: 2,
+			Size:    3,
+			Total:   6,
+			Min:     ef.NewOptValue(1),
+			Max:     ef.NewOptValue(3),
+		},
+		collectVals(Stats[int](), 3, 1, 2))
+	assert.Equal(t, ef.SummaryStats[int]{}, collectVals(Stats[int]()))
+}
+
+func TestJoining(t *testing.T) {
+	assert.Equal(t, "a-b-c", collectVals(Joining("-"), "a", "b", "c"))
+	assert.Equal(t, "-a", collectVals(Joining("-"), "", "a"))
+	assert.Equal(t, "", collectVals(Joining("-")))
+}
+
+func TestMapping(t *testing.T) {
+	lengths := Mapping(func(s string) int { return len(s) }, Summing[int]())
+	assert.Equal(t, 6, collectVals(lengths, "a", "bb", "ccc"))
+}
+
+func TestFiltering(t *testing.T) {
+	evens := Filtering(func(v int) bool { return v%2 == 0 }, ToSlice[int]())
+	assert.Equal(t, ef.Slice(2, 4), collectVals(evens, 1, 2, 3, 4, 5))
+}
+
+func TestTeeing(t *testing.T) {
+	avg := Teeing(Summing[int](), Counting[int](), func(sum, count int) float64 {
+		return float64(sum) / float64(count)
+	})
+	assert.Equal(t, 2.0, collectVals(avg, 1, 2, 3))
+}
+
+func TestGroupingBy(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		byLen := GroupingBy(func(s string) int { return len(s) }, ToSlice[string]())
+		assert.Equal(t,
+			map[int][]string{
+				1: ef.Slice("a", "b"),
+				2: ef.Slice("cc"),
+			},
+			collectVals(byLen, "a", "cc", "b"))
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		type employee struct {
+			dept   string
+			salary int
+		}
+		avgSalaryByDept := GroupingBy(
+			func(e employee) string { return e.dept },
+			Mapping(func(e employee) int { return e.salary }, Averaging[int]()))
+		assert.Equal(t,
+			map[string]float64{
+				"eng":   15,
+				"sales": 8,
+			},
+			collectVals(avgSalaryByDept,
+				employee{"eng", 10},
+				employee{"sales", 8},
+				employee{"eng", 20}))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		c := GroupingBy(func(s string) int { return len(s) }, Counting[string]())
+		assert.Equal(t, map[int]int{}, collectVals(c))
+	})
+}
+
+func TestPartitioning(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+	assert.Equal(t,
+		ef.PairOf(ef.Slice(2, 4), ef.Slice(1, 3, 5)),
+		collectVals(Partitioning(isEven, ToSlice[int]()), 1, 2, 3, 4, 5))
+	assert.Equal(t,
+		ef.PairOf(2, 3),
+		collectVals(Partitioning(isEven, Counting[int]()), 1, 2, 3, 4, 5))
+}
+
+func TestNested(t *testing.T) {
+	c := Mapping(
+		strings.ToUpper,
+		Filtering(
+			func(s string) bool { return s != "" },
+			Teeing(Joining(","), Counting[string](), ef.PairOf[string, int])))
+	assert.Equal(t,
+		ef.PairOf("A,B", 2),
+		collectVals(c, "a", "", "b"))
+}
+
+func collectVals[T, A, R any](c ef.Collector[T, A, R], vals ...T) R {
+	acc := c.Supply()
+	for _, v := range vals {
+		acc = c.Accumulate(acc, v)
+	}
+	return c.Finish(acc)
+}
@@ -0,0 +1,105 @@
+package collect
+
+import (
+	"slices"
+
+	"github.com/BennettJames/ef"
+)
+
+// StatsChecked returns a collector that calculates the SummaryStats object for
+// a set of numbers, or an error result with an `*ef.OverflowError` if the total
+// overflows the number type. Values after an overflow are ignored.
+func StatsChecked[N ef.Number]() ef.Collector[N, ef.Res[ef.SummaryStats[N]], ef.Res[ef.SummaryStats[N]]] {
+	return Reducing(
+		ef.NewResValue(ef.SummaryStats[N]{}),
+		func(stats ef.Res[ef.SummaryStats[N]], v N) ef.Res[ef.SummaryStats[N]] {
+			if stats.IsErr() {
+				return stats
+			}
+			return stats.Val().AddChecked(v)
+		})
+}
+
+// StatsWide returns a collector that calculates the WideSummaryStats object for
+// a set of integers, which tracks the total without overflow.
+func StatsWide[N ef.Integer]() ef.Collector[N, *ef.WideSummaryStats[N], ef.WideSummaryStats[N]] {
+	return ef.Collector[N, *ef.WideSummaryStats[N], ef.WideSummaryStats[N]]{
+		Supply: ef.NewWideSummaryStats[N],
+		Accumulate: func(stats *ef.WideSummaryStats[N], v N) *ef.WideSummaryStats[N] {
+			stats.Add(v)
+			return stats
+		},
+		Finish: func(stats *ef.WideSummaryStats[N]) ef.WideSummaryStats[N] {
+			return *stats
+		},
+	}
+}
+
+// StatsExt returns a collector that calculates the SummaryStatsExt object for a
+// set of numbers.
+func StatsExt[N ef.Number]() ef.Collector[N, ef.SummaryStatsExt[N], ef.SummaryStatsExt[N]] {
+	return ef.Collector[N, ef.SummaryStatsExt[N], ef.SummaryStatsExt[N]]{
+		Supply: ef.NewSummaryStatsExt[N],
+		Accumulate: func(stats ef.SummaryStatsExt[N], v N) ef.SummaryStatsExt[N] {
+			return stats.Add(v)
+		},
+		Finish: identity[ef.SummaryStatsExt[N]],
+	}
+}
+
+// Percentile returns a collector that calculates the exact given percentile
+// (between 0 and 100) of a set of numbers, interpolating between the closest
+// values. The result is empty if there are no values.
+//
+// Note this holds every value in memory; `ApproxPercentile` avoids that.
+func Percentile[N ef.Number](percentile float64) ef.Collector[N, []float64, ef.Opt[float64]] {
+	assertPercentile(percentile)
+	return ef.Collector[N, []float64, ef.Opt[float64]]{
+		Supply: func() []float64 {
+			return make([]float64, 0)
+		},
+		Accumulate: func(acc []float64, v N) []float64 {
+			return append(acc, float64(v))
+		},
+		Finish: func(acc []float64) ef.Opt[float64] {
+			slices.Sort(acc)
+			return ef.Percentile(acc, percentile)
+		},
+	}
+}
+
+// Median returns a collector that calculates the exact median of a set of
+// numbers. The result is empty if there are no values.
+func Median[N ef.Number]() ef.Collector[N, []float64, ef.Opt[float64]] {
+	return Percentile[N](50)
+}
+
+// ApproxPercentile returns a collector that estimates the given percentile
+// (between 0 and 100) of a set of numbers in constant memory. See
+// `ef.QuantileSketch` for details.
+func ApproxPercentile[N ef.Number](
+	percentile float64,
+) ef.Collector[N, *ef.QuantileSketch, ef.Opt[float64]] {
+	// the sketch checks the percentile, so building one up front panics early
+	// on a bad value; each collection starts from a copy of it.
+	initial := ef.NewQuantileSketch(percentile)
+	return ef.Collector[N, *ef.QuantileSketch, ef.Opt[float64]]{
+		Supply: func() *ef.QuantileSketch {
+			qs := *initial
+			return &qs
+		},
+		Accumulate: func(qs *ef.QuantileSketch, v N) *ef.QuantileSketch {
+			qs.Add(float64(v))
+			return qs
+		},
+		Finish: func(qs *ef.QuantileSketch) ef.Opt[float64] {
+			return qs.Value()
+		},
+	}
+}
+
+func assertPercentile(percentile float64) {
+	ef.AssertMsgf(
+		percentile >= 0 && percentile <= 100,
+		"percentile must be between 0 and 100, got %v", percentile)
+}
@@ -0,0 +1,92 @@
+package collect
+
+import (
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsChecked(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		stats := collectVals(StatsChecked[int8](), 100, -50, 27)
+		assert.Equal(t, int8(77), stats.Val().Total)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		stats := collectVals(StatsChecked[int8](), 100, 50, -100)
+		var overflowErr *ef.OverflowError[int8]
+		assert.ErrorAs(t, stats.Err(), &overflowErr)
+	})
+}
+
+func TestStatsWide(t *testing.T) {
+	stats := collectVals(StatsWide[int8](), 100, 100, 100)
+	assert.Equal(t, int64(300), stats.Total.Int64())
+	assert.Equal(t, 100.0, stats.Average)
+	assert.Equal(t, ef.NewOptValue[int8](100), stats.Max)
+}
+
+func TestStatsExt(t *testing.T) {
+	stats := collectVals(StatsExt[int](), 2, 4, 4, 4, 5, 5, 7, 9)
+	assert.Equal(t, 40, stats.Total)
+	assert.Equal(t, 5.0, stats.Average)
+	assert.Equal(t, 4.0, stats.Variance)
+	assert.Equal(t, 2.0, stats.StdDev)
+	assert.Equal(t, ef.NewOptValue(2), stats.Min)
+	assert.Equal(t, ef.NewOptValue(9), stats.Max)
+}
+
+func TestPercentile(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.NewOptValue(4.0),
+			collectVals(Percentile[int](75), 5, 1, 4, 2, 3))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, ef.Opt[float64]{}, collectVals(Percentile[int](75)))
+	})
+
+	t.Run("BadPercentile", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Percentile[int](-1)
+		})
+	})
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, ef.NewOptValue(3.0), collectVals(Median[int](), 5, 1, 4, 2, 3))
+	assert.Equal(t, ef.NewOptValue(2.5), collectVals(Median[int](), 4, 1, 2, 3))
+}
+
+func TestApproxPercentile(t *testing.T) {
+	t.Run("Small", func(t *testing.T) {
+		assert.Equal(t,
+			ef.NewOptValue(3.0),
+			collectVals(ApproxPercentile[int](50), 5, 1, 4, 2, 3))
+	})
+
+	t.Run("Large", func(t *testing.T) {
+		vals := make([]int, 0, 10_000)
+		for i := 0; i < 10_000; i++ {
+			vals = append(vals, (i*7919)%10_000)
+		}
+		approx := collectVals(ApproxPercentile[int](90), vals...)
+		assert.InDelta(t, 9000, approx.UnsafeGet(), 100)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, ef.Opt[float64]{}, collectVals(ApproxPercentile[int](50)))
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		c := ApproxPercentile[int](50)
+		assert.Equal(t, ef.NewOptValue(3.0), collectVals(c, 5, 1, 4, 2, 3))
+		assert.Equal(t, ef.NewOptValue(1.0), collectVals(c, 1))
+	})
+
+	t.Run("BadPercentile", func(t *testing.T) {
+		assert.Panics(t, func() { ApproxPercentile[int](101) })
+	})
+}
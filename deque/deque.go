@@ -0,0 +1,146 @@
+package deque
+
+import "github.com/BennettJames/ef"
+
+type (
+	// Deque is a double-ended queue, which can have values added and removed at
+	// either end in constant time.
+	//
+	// It is backed by a ring buffer that grows as needed but never shrinks, so
+	// once it has reached its working size, pushing and popping don't allocate.
+	// The zero value is an empty deque ready to use.
+	Deque[T any] struct {
+		buf  []T
+		head int
+		size int
+	}
+)
+
+// New creates an empty deque with room for `capacity` values before it needs
+// to grow.
+func New[T any](capacity int) *Deque[T] {
+	ef.AssertMsgf(capacity >= 0, "deque capacity must not be negative - got %d", capacity)
+	return &Deque[T]{
+		buf: make([]T, capacity),
+	}
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// PushBack adds the value to the back of the deque.
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+	d.buf[d.index(d.size)] = val
+	d.size++
+}
+
+// PushFront adds the value to the front of the deque.
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = val
+	d.size++
+}
+
+// PopFront removes and returns the value at the front of the deque, or returns
+// an empty optional if the deque is empty.
+func (d *Deque[T]) PopFront() ef.Opt[T] {
+	if d.size == 0 {
+		return ef.Opt[T]{}
+	}
+	var zero T
+	val := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	return ef.NewOptValue(val)
+}
+
+// PopBack removes and returns the value at the back of the deque, or returns an
+// empty optional if the deque is empty.
+func (d *Deque[T]) PopBack() ef.Opt[T] {
+	if d.size == 0 {
+		return ef.Opt[T]{}
+	}
+	var zero T
+	i := d.index(d.size - 1)
+	val := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	return ef.NewOptValue(val)
+}
+
+// Front returns the value at the front of the deque without removing it, or an
+// empty optional if the deque is empty.
+func (d *Deque[T]) Front() ef.Opt[T] {
+	return d.At(0)
+}
+
+// Back returns the value at the back of the deque without removing it, or an
+// empty optional if the deque is empty.
+func (d *Deque[T]) Back() ef.Opt[T] {
+	return d.At(d.size - 1)
+}
+
+// At returns the value at position `i` counting from the front, or an empty
+// optional if `i` is out of range.
+func (d *Deque[T]) At(i int) ef.Opt[T] {
+	if i < 0 || i >= d.size {
+		return ef.Opt[T]{}
+	}
+	return ef.NewOptValue(d.buf[d.index(i)])
+}
+
+// Clear removes every value from the deque, but keeps its capacity.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.size = 0
+}
+
+// Stream returns a stream of the deque's values from front to back.
+func (d *Deque[T]) Stream() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for i := 0; i < d.size; i++ {
+				if !opFn(d.buf[d.index(i)]) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// ReverseStream returns a stream of the deque's values from back to front.
+func (d *Deque[T]) ReverseStream() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for i := d.size - 1; i >= 0; i-- {
+				if !opFn(d.buf[d.index(i)]) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// index converts a position relative to the head into an index in the buffer.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// grow doubles the size of the buffer if it is full, unwrapping the values so
+// the head is back at the start.
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	newBuf := make([]T, max(2*len(d.buf), 8))
+	n := copy(newBuf, d.buf[d.head:])
+	copy(newBuf[n:], d.buf[:d.head])
+	d.buf = newBuf
+	d.head = 0
+}
@@ -0,0 +1,103 @@
+package deque
+
+import (
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequePushPop(t *testing.T) {
+	t.Run("Back", func(t *testing.T) {
+		var d Deque[int]
+		d.PushBack(1)
+		d.PushBack(2)
+		assert.Equal(t, 2, d.Len())
+		assert.Equal(t, ef.NewOptValue(2), d.PopBack())
+		assert.Equal(t, ef.NewOptValue(1), d.PopBack())
+		assert.Equal(t, ef.Opt[int]{}, d.PopBack())
+	})
+
+	t.Run("Front", func(t *testing.T) {
+		var d Deque[int]
+		d.PushFront(1)
+		d.PushFront(2)
+		assert.Equal(t, ef.NewOptValue(2), d.PopFront())
+		assert.Equal(t, ef.NewOptValue(1), d.PopFront())
+		assert.Equal(t, ef.Opt[int]{}, d.PopFront())
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		d := New[int](2)
+		for i := 0; i < 10; i++ {
+			d.PushBack(i)
+			d.PushFront(-i)
+		}
+		assert.Equal(t, 20, d.Len())
+		assert.Equal(t, ef.NewOptValue(-9), d.Front())
+		assert.Equal(t, ef.NewOptValue(9), d.Back())
+		assert.Equal(t, ef.NewOptValue(-9), d.PopFront())
+		assert.Equal(t, ef.NewOptValue(9), d.PopBack())
+		assert.Equal(t, 18, d.Len())
+	})
+}
+
+func TestDequeAt(t *testing.T) {
+	d := New[string](0)
+	d.PushBack("b")
+	d.PushFront("a")
+	d.PushBack("c")
+	assert.Equal(t, ef.NewOptValue("a"), d.At(0))
+	assert.Equal(t, ef.NewOptValue("c"), d.At(2))
+	assert.Equal(t, ef.Opt[string]{}, d.At(3))
+	assert.Equal(t, ef.Opt[string]{}, d.At(-1))
+
+	var empty Deque[string]
+	assert.Equal(t, ef.Opt[string]{}, empty.Front())
+	assert.Equal(t, ef.Opt[string]{}, empty.Back())
+}
+
+func TestDequeClear(t *testing.T) {
+	d := New[int](4)
+	d.PushBack(1)
+	d.PushBack(2)
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+	d.PushFront(3)
+	assert.Equal(t, ef.Slice(3), d.Stream().ToSlice())
+}
+
+func TestDequeStream(t *testing.T) {
+	d := New[int](4)
+	for i := 1; i <= 4; i++ {
+		d.PushBack(i)
+	}
+	d.PopFront()
+	d.PushBack(5)
+	assert.Equal(t, ef.Slice(2, 3, 4, 5), d.Stream().ToSlice())
+	assert.Equal(t, ef.Slice(5, 4, 3, 2), d.ReverseStream().ToSlice())
+
+	vals := make([]int, 0)
+	d.Stream().ExitableEach(func(v int) bool {
+		vals = append(vals, v)
+		return v < 3
+	})
+	assert.Equal(t, ef.Slice(2, 3), vals)
+}
+
+func TestDequeAllocs(t *testing.T) {
+	d := New[int](16)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 16; i++ {
+			d.PushBack(i)
+		}
+		for i := 0; i < 8; i++ {
+			d.PopFront()
+			d.PushFront(i)
+		}
+		for d.Len() > 0 {
+			d.PopBack()
+		}
+	})
+	assert.Equal(t, 0.0, allocs)
+}
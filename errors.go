@@ -11,6 +11,26 @@ type (
 
 	// UnreachableError is designed to be thrown
 	UnreachableError struct{}
+
+	// DuplicateKeyError indicates that two values were found for the same key
+	// when only one was expected - e.g. when gathering a pair stream into a map.
+	DuplicateKeyError[K, V any] struct {
+		Key       K
+		Existing  V
+		Duplicate V
+	}
+
+	// NotSingleError indicates that exactly one value was expected, but there
+	// were either none or several.
+	NotSingleError struct {
+		Empty bool
+	}
+
+	// OverflowError indicates that adding two numbers exceeded the range of
+	// their type.
+	OverflowError[N Number] struct {
+		V1, V2 N
+	}
 )
 
 func NewRecoverError(err any) *RecoverError {
@@ -41,6 +61,23 @@ func (e *UnreachableError) Error() string {
 	return "unreachable"
 }
 
+func (e *DuplicateKeyError[K, V]) Error() string {
+	return fmt.Sprintf(
+		"duplicate values found for key '%v' - ['%v', '%v']",
+		e.Key, e.Existing, e.Duplicate)
+}
+
+func (e *NotSingleError) Error() string {
+	if e.Empty {
+		return "expected a single value, found none"
+	}
+	return "expected a single value, found more than one"
+}
+
+func (e *OverflowError[N]) Error() string {
+	return fmt.Sprintf("overflow adding '%v' and '%v' as %T", e.V1, e.V2, e.V1)
+}
+
 func Recover(errAddr *error) {
 	if errAddr == nil {
 		panic("Recover called with nil result reference")
@@ -101,6 +138,13 @@ func Try3[T, U, V any](t T, u U, v V, err error) (T, U, V) {
 	return t, u, v
 }
 
+func Try4[T, U, V, W any](t T, u U, v V, w W, err error) (T, U, V, W) {
+	if err != nil {
+		panic(err)
+	}
+	return t, u, v, w
+}
+
 func Assert(check bool) {
 	if !check {
 		// todo [bs]: wrap this
@@ -0,0 +1,50 @@
+package ef
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTry(t *testing.T) {
+	err := fmt.Errorf("error")
+
+	t.Run("Try", func(t *testing.T) {
+		assert.Equal(t, "a", Try("a", nil))
+		assert.PanicsWithError(t, "error", func() { Try("a", err) })
+	})
+
+	t.Run("Try2", func(t *testing.T) {
+		v1, v2 := Try2("a", 1, nil)
+		assert.Equal(t, "a", v1)
+		assert.Equal(t, 1, v2)
+		assert.PanicsWithError(t, "error", func() { Try2("a", 1, err) })
+	})
+
+	t.Run("Try3", func(t *testing.T) {
+		v1, v2, v3 := Try3("a", 1, true, nil)
+		assert.Equal(t, "a", v1)
+		assert.Equal(t, 1, v2)
+		assert.Equal(t, true, v3)
+		assert.PanicsWithError(t, "error", func() { Try3("a", 1, true, err) })
+	})
+
+	t.Run("Try4", func(t *testing.T) {
+		v1, v2, v3, v4 := Try4("a", 1, true, 1.5, nil)
+		assert.Equal(t, "a", v1)
+		assert.Equal(t, 1, v2)
+		assert.Equal(t, true, v3)
+		assert.Equal(t, 1.5, v4)
+		assert.PanicsWithError(t, "error", func() { Try4("a", 1, true, 1.5, err) })
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		tryFn := func() (err error) {
+			defer Recover(&err)
+			Try4("a", 1, true, 1.5, fmt.Errorf("inner"))
+			return nil
+		}
+		assert.EqualError(t, tryFn(), "inner")
+	})
+}
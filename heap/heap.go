@@ -0,0 +1,211 @@
+package heap
+
+import (
+	"cmp"
+
+	"github.com/BennettJames/ef"
+)
+
+type (
+	// Heap is a priority queue, which always gives back its values in order of
+	// the heap's less function - i.e. the "least" value comes out first.
+	Heap[T any] struct {
+		entries []*Handle[T]
+		less    func(a, b T) bool
+	}
+
+	// Handle refers to a value pushed onto a heap, so that it can later be
+	// fixed or removed without searching for it.
+	Handle[T any] struct {
+		val   T
+		index int
+	}
+)
+
+// New creates an empty heap, ordered with the given less function.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{
+		less: less,
+	}
+}
+
+// NewOrdered creates an empty min-heap of an ordered type.
+func NewOrdered[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// Value returns the value the handle refers to.
+func (h *Handle[T]) Value() T {
+	return h.val
+}
+
+// Len returns the number of values in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.entries)
+}
+
+// Push adds the value to the heap, and returns a handle to it.
+func (h *Heap[T]) Push(val T) *Handle[T] {
+	e := &Handle[T]{
+		val:   val,
+		index: len(h.entries),
+	}
+	h.entries = append(h.entries, e)
+	h.up(e.index)
+	return e
+}
+
+// Heapify adds all the values to the heap at once. This takes linear time,
+// which is faster than pushing them one at a time when adding many values.
+func (h *Heap[T]) Heapify(vals ...T) {
+	for _, v := range vals {
+		h.entries = append(h.entries, &Handle[T]{
+			val:   v,
+			index: len(h.entries),
+		})
+	}
+	for i := len(h.entries)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// Peek returns the least value in the heap without removing it, or an empty
+// optional if the heap is empty.
+func (h *Heap[T]) Peek() ef.Opt[T] {
+	if len(h.entries) == 0 {
+		return ef.Opt[T]{}
+	}
+	return ef.NewOptValue(h.entries[0].val)
+}
+
+// Pop removes and returns the least value in the heap, or returns an empty
+// optional if the heap is empty.
+func (h *Heap[T]) Pop() ef.Opt[T] {
+	if len(h.entries) == 0 {
+		return ef.Opt[T]{}
+	}
+	return ef.NewOptValue(h.removeAt(0).val)
+}
+
+// ReplaceTop swaps the least value in the heap for the given one, and returns
+// the value that was replaced. If the heap is empty, the value is just pushed
+// and an empty optional is returned.
+//
+// This is equivalent to a Pop followed by a Push, but is faster and doesn't
+// allocate.
+func (h *Heap[T]) ReplaceTop(val T) ef.Opt[T] {
+	if len(h.entries) == 0 {
+		h.Push(val)
+		return ef.Opt[T]{}
+	}
+	top := h.entries[0]
+	old := top.val
+	top.val = val
+	h.down(0)
+	return ef.NewOptValue(old)
+}
+
+// Fix restores the heap's order after the handle's value has changed - e.g. if
+// the heap holds pointers and the value was modified in place. It returns
+// false if the handle is no longer in the heap.
+func (h *Heap[T]) Fix(e *Handle[T]) bool {
+	if !h.owns(e) {
+		return false
+	}
+	if !h.down(e.index) {
+		h.up(e.index)
+	}
+	return true
+}
+
+// Update sets the handle's value, and moves it to its new place in the heap.
+// It returns false if the handle is no longer in the heap.
+func (h *Heap[T]) Update(e *Handle[T], val T) bool {
+	if !h.owns(e) {
+		return false
+	}
+	e.val = val
+	return h.Fix(e)
+}
+
+// Remove takes the handle's value out of the heap, and returns false if it was
+// no longer there.
+func (h *Heap[T]) Remove(e *Handle[T]) bool {
+	if !h.owns(e) {
+		return false
+	}
+	h.removeAt(e.index)
+	return true
+}
+
+// Drain returns a stream that pops values from the heap in order. If the stream
+// is stopped early, any values not yet reached are left in the heap.
+func (h *Heap[T]) Drain() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for len(h.entries) > 0 {
+				if !opFn(h.removeAt(0).val) {
+					return
+				}
+			}
+		},
+	})
+}
+
+func (h *Heap[T]) owns(e *Handle[T]) bool {
+	return e.index >= 0 && e.index < len(h.entries) && h.entries[e.index] == e
+}
+
+func (h *Heap[T]) removeAt(i int) *Handle[T] {
+	last := len(h.entries) - 1
+	e := h.entries[i]
+	if i != last {
+		h.swap(i, last)
+	}
+	h.entries[last] = nil
+	h.entries = h.entries[:last]
+	if i != last && !h.down(i) {
+		h.up(i)
+	}
+	e.index = -1
+	return e
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.entries[i].val, h.entries[parent].val) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the entry at `i` towards the leaves until it is in order, and
+// indicates whether it moved at all.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.entries)
+	for {
+		least := 2*i + 1
+		if least >= n {
+			break
+		}
+		if right := least + 1; right < n && h.less(h.entries[right].val, h.entries[least].val) {
+			least = right
+		}
+		if !h.less(h.entries[least].val, h.entries[i].val) {
+			break
+		}
+		h.swap(i, least)
+		i = least
+	}
+	return i > start
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].index = i
+	h.entries[j].index = j
+}
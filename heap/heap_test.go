@@ -0,0 +1,124 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeapPushPop(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		h := NewOrdered[int]()
+		for _, v := range ef.Slice(5, 1, 4, 2, 3) {
+			h.Push(v)
+		}
+		assert.Equal(t, 5, h.Len())
+		assert.Equal(t, ef.NewOptValue(1), h.Peek())
+		assert.Equal(t, ef.NewOptValue(1), h.Pop())
+		assert.Equal(t, ef.NewOptValue(2), h.Pop())
+		assert.Equal(t, 3, h.Len())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		h := NewOrdered[int]()
+		assert.Equal(t, ef.Opt[int]{}, h.Peek())
+		assert.Equal(t, ef.Opt[int]{}, h.Pop())
+	})
+
+	t.Run("Less", func(t *testing.T) {
+		h := New(func(a, b string) bool { return len(a) > len(b) })
+		h.Push("a")
+		h.Push("ccc")
+		h.Push("bb")
+		assert.Equal(t, ef.Slice("ccc", "bb", "a"), h.Drain().ToSlice())
+	})
+}
+
+func TestHeapHeapify(t *testing.T) {
+	vals := rand.Perm(100)
+	h := NewOrdered[int]()
+	h.Push(-1)
+	h.Heapify(vals...)
+	expected := append(ef.Slice(-1), vals...)
+	slices.Sort(expected)
+	assert.Equal(t, expected, h.Drain().ToSlice())
+}
+
+func TestHeapReplaceTop(t *testing.T) {
+	h := NewOrdered[int]()
+	assert.Equal(t, ef.Opt[int]{}, h.ReplaceTop(3))
+	h.Push(1)
+	h.Push(2)
+	assert.Equal(t, ef.NewOptValue(1), h.ReplaceTop(4))
+	assert.Equal(t, ef.Slice(2, 3, 4), h.Drain().ToSlice())
+}
+
+func TestHeapHandles(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		h := NewOrdered[int]()
+		h1, h2, h3 := h.Push(1), h.Push(2), h.Push(3)
+		assert.True(t, h.Update(h3, 0))
+		assert.True(t, h.Update(h1, 4))
+		assert.Equal(t, 0, h3.Value())
+		assert.Equal(t, ef.NewOptValue(0), h.Pop())
+		assert.False(t, h.Update(h3, 5))
+		assert.Equal(t, 2, h2.Value())
+		assert.Equal(t, ef.Slice(2, 4), h.Drain().ToSlice())
+	})
+
+	t.Run("Fix", func(t *testing.T) {
+		h := New(func(a, b *int) bool { return *a < *b })
+		v1, v2 := 1, 2
+		h.Push(&v1)
+		handle := h.Push(&v2)
+		v2 = 0
+		assert.True(t, h.Fix(handle))
+		assert.Equal(t, &v2, h.Pop().UnsafeGet())
+		assert.False(t, h.Fix(handle))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		h := NewOrdered[int]()
+		handles := make([]*Handle[int], 0)
+		for _, v := range ef.Slice(5, 1, 4, 2, 3) {
+			handles = append(handles, h.Push(v))
+		}
+		assert.True(t, h.Remove(handles[2]))
+		assert.False(t, h.Remove(handles[2]))
+		assert.True(t, h.Remove(handles[1]))
+		assert.Equal(t, ef.Slice(2, 3, 5), h.Drain().ToSlice())
+		assert.False(t, h.Remove(handles[0]))
+	})
+
+	t.Run("OtherHeap", func(t *testing.T) {
+		h1, h2 := NewOrdered[int](), NewOrdered[int]()
+		handle := h1.Push(1)
+		h2.Push(2)
+		assert.False(t, h2.Remove(handle))
+		assert.Equal(t, 1, h2.Len())
+	})
+}
+
+func TestHeapDrain(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		h := NewOrdered[int]()
+		h.Heapify(3, 1, 2)
+		assert.Equal(t, ef.Slice(1, 2, 3), h.Drain().ToSlice())
+		assert.Equal(t, 0, h.Len())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		h := NewOrdered[int]()
+		h.Heapify(3, 1, 2, 4)
+		vals := make([]int, 0)
+		h.Drain().ExitableEach(func(v int) bool {
+			vals = append(vals, v)
+			return v < 2
+		})
+		assert.Equal(t, ef.Slice(1, 2), vals)
+		assert.Equal(t, ef.Slice(3, 4), h.Drain().ToSlice())
+	})
+}
@@ -52,7 +52,14 @@ func (fi *FnIter[T]) Next(opFn func(T) bool) {
 }
 
 func (ms *MultiStream[T]) Next(opFn func(T) (advance bool)) {
+	advance := true
 	for _, st := range ms.Streams {
-		st.srcIter.Next(opFn)
+		st.srcIter.Next(func(val T) bool {
+			advance = opFn(val)
+			return advance
+		})
+		if !advance {
+			return
+		}
 	}
 }
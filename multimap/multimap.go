@@ -0,0 +1,139 @@
+package multimap
+
+import (
+	"slices"
+
+	"github.com/BennettJames/ef"
+)
+
+type (
+	// MultiMap is a map where each key can have any number of values. Values
+	// are kept in the order they were added for each key; the order of keys is
+	// random, as with a regular map.
+	//
+	// The zero value is an empty multimap ready to use.
+	MultiMap[K, V comparable] struct {
+		entries map[K][]V
+		size    int
+	}
+)
+
+// New creates an empty multimap.
+func New[K, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		entries: make(map[K][]V),
+	}
+}
+
+// Put adds values for the key, after any it already has.
+func (m *MultiMap[K, V]) Put(key K, vals ...V) {
+	if len(vals) == 0 {
+		return
+	}
+	if m.entries == nil {
+		m.entries = make(map[K][]V)
+	}
+	m.entries[key] = append(m.entries[key], vals...)
+	m.size += len(vals)
+}
+
+// Get returns the values for the key, or an empty slice if it has none. The
+// slice is a copy, so is safe to modify.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	vals := m.entries[key]
+	if len(vals) == 0 {
+		return make([]V, 0)
+	}
+	return slices.Clone(vals)
+}
+
+// Contains indicates if the key has any values.
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	_, exists := m.entries[key]
+	return exists
+}
+
+// ContainsValue indicates if the key has the given value.
+func (m *MultiMap[K, V]) ContainsValue(key K, val V) bool {
+	return slices.Contains(m.entries[key], val)
+}
+
+// RemoveValue removes the first occurrence of the value from the key's values,
+// and indicates if there was one to remove.
+func (m *MultiMap[K, V]) RemoveValue(key K, val V) bool {
+	vals := m.entries[key]
+	index := slices.Index(vals, val)
+	if index < 0 {
+		return false
+	}
+	if len(vals) == 1 {
+		delete(m.entries, key)
+	} else {
+		m.entries[key] = slices.Delete(vals, index, index+1)
+	}
+	m.size--
+	return true
+}
+
+// RemoveKey removes the key and all its values, and returns the values that
+// were removed.
+func (m *MultiMap[K, V]) RemoveKey(key K) []V {
+	vals, exists := m.entries[key]
+	if !exists {
+		return make([]V, 0)
+	}
+	delete(m.entries, key)
+	m.size -= len(vals)
+	return vals
+}
+
+// Keys returns every key that has at least one value, in no particular order.
+func (m *MultiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Len returns the total number of values across all keys.
+func (m *MultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// KeyLen returns the number of keys that have at least one value.
+func (m *MultiMap[K, V]) KeyLen() int {
+	return len(m.entries)
+}
+
+// Stream returns a stream of a key/value pair for every value in the map.
+// Values for the same key are adjacent and in order, but the order of keys is
+// random.
+func (m *MultiMap[K, V]) Stream() ef.Stream[ef.Pair[K, V]] {
+	return ef.NewStream[ef.Pair[K, V]](&ef.FnIter[ef.Pair[K, V]]{
+		Fn: func(opFn func(ef.Pair[K, V]) bool) {
+			for k, vals := range m.entries {
+				for _, v := range vals {
+					if !opFn(ef.PairOf(k, v)) {
+						return
+					}
+				}
+			}
+		},
+	})
+}
+
+// Collect returns a collector that gathers pairs into a multimap, keeping every
+// value for each key in the order they are collected.
+func Collect[K, V comparable]() ef.Collector[ef.Pair[K, V], *MultiMap[K, V], *MultiMap[K, V]] {
+	return ef.Collector[ef.Pair[K, V], *MultiMap[K, V], *MultiMap[K, V]]{
+		Supply: New[K, V],
+		Accumulate: func(acc *MultiMap[K, V], p ef.Pair[K, V]) *MultiMap[K, V] {
+			acc.Put(p.First, p.Second)
+			return acc
+		},
+		Finish: func(acc *MultiMap[K, V]) *MultiMap[K, V] {
+			return acc
+		},
+	}
+}
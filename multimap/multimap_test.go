@@ -0,0 +1,111 @@
+package multimap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiMapPut(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 2, 3)
+	m.Put("b", 4)
+	m.Put("c")
+	assert.Equal(t, ef.Slice(1, 2, 3), m.Get("a"))
+	assert.Equal(t, ef.Slice(4), m.Get("b"))
+	assert.Equal(t, ef.Slice[int](), m.Get("c"))
+	assert.False(t, m.Contains("c"))
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, 2, m.KeyLen())
+}
+
+func TestMultiMapZero(t *testing.T) {
+	var m MultiMap[string, int]
+	assert.Equal(t, ef.Slice[int](), m.Get("a"))
+	assert.False(t, m.RemoveValue("a", 1))
+	m.Put("a", 1, 2)
+	assert.Equal(t, ef.Slice(1, 2), m.Get("a"))
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestMultiMapGet(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1, 2)
+	vals := m.Get("a")
+	vals[0] = 10
+	assert.Equal(t, ef.Slice(1, 2), m.Get("a"))
+}
+
+func TestMultiMapContainsValue(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1, 2)
+	assert.True(t, m.ContainsValue("a", 2))
+	assert.False(t, m.ContainsValue("a", 3))
+	assert.False(t, m.ContainsValue("b", 1))
+}
+
+func TestMultiMapRemoveValue(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		m := New[string, int]()
+		m.Put("a", 1, 2, 1)
+		assert.True(t, m.RemoveValue("a", 1))
+		assert.Equal(t, ef.Slice(2, 1), m.Get("a"))
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("Last", func(t *testing.T) {
+		m := New[string, int]()
+		m.Put("a", 1)
+		assert.True(t, m.RemoveValue("a", 1))
+		assert.False(t, m.Contains("a"))
+		assert.Equal(t, 0, m.Len())
+		assert.Equal(t, 0, m.KeyLen())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		m := New[string, int]()
+		m.Put("a", 1)
+		assert.False(t, m.RemoveValue("a", 2))
+		assert.False(t, m.RemoveValue("b", 1))
+		assert.Equal(t, 1, m.Len())
+	})
+}
+
+func TestMultiMapRemoveKey(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1, 2)
+	m.Put("b", 3)
+	assert.Equal(t, ef.Slice(1, 2), m.RemoveKey("a"))
+	assert.Equal(t, ef.Slice[int](), m.RemoveKey("a"))
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, ef.Slice("b"), m.Keys())
+}
+
+func TestMultiMapStream(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1, 2)
+	m.Put("b", 3)
+	pairs := m.Stream().ToSlice()
+	slices.SortStableFunc(pairs, func(p1, p2 ef.Pair[string, int]) int {
+		return strings.Compare(p1.First, p2.First)
+	})
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 1), ef.PairOf("a", 2), ef.PairOf("b", 3)),
+		pairs)
+}
+
+func TestMultiMapCollect(t *testing.T) {
+	c := Collect[string, int]()
+	acc := c.Supply()
+	for _, p := range ef.Slice(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 3)) {
+		acc = c.Accumulate(acc, p)
+	}
+	m := c.Finish(acc)
+	assert.Equal(t, ef.Slice(1, 3), m.Get("a"))
+	assert.Equal(t, ef.Slice(2), m.Get("b"))
+	assert.Equal(t, 3, m.Len())
+}
@@ -183,6 +183,24 @@ func Add[N AllNumber](v1, v2 N) N {
 	return v1 + v2
 }
 
+// AddChecked adds two numbers of the same type, or returns an error result
+// with an `*OverflowError` if the sum is outside the range of the type. For
+// floats, overflow means two finite numbers summing to an infinite one.
+func AddChecked[N Number](v1, v2 N) Res[N] {
+	sum := v1 + v2
+	var overflow bool
+	if isFloat[N]() {
+		overflow = math.IsInf(float64(sum), 0) &&
+			!math.IsInf(float64(v1), 0) && !math.IsInf(float64(v2), 0)
+	} else {
+		overflow = (v2 > 0 && sum < v1) || (v2 < 0 && sum > v1)
+	}
+	if overflow {
+		return NewResError[N](&OverflowError[N]{V1: v1, V2: v2})
+	}
+	return NewResValue(sum)
+}
+
 // Mult multiplies two number of the same type together.
 func Mult[N AllNumber](v1, v2 N) N {
 	return v1 * v2
@@ -279,3 +279,30 @@ func (s eachStreamerRangeGeneric[T]) each(fn func(T)) {
 		fn(i)
 	}
 }
+
+func TestAddChecked(t *testing.T) {
+	t.Run("Signed", func(t *testing.T) {
+		assert.Equal(t, NewResValue[int8](127), AddChecked[int8](100, 27))
+		assert.Equal(t, NewResValue[int8](-128), AddChecked[int8](-100, -28))
+		assert.True(t, AddChecked[int8](100, 28).IsErr())
+		assert.True(t, AddChecked[int8](-100, -29).IsErr())
+		assert.True(t, AddChecked(math.MaxInt, 1).IsErr())
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		assert.Equal(t, NewResValue[uint8](255), AddChecked[uint8](200, 55))
+		assert.True(t, AddChecked[uint8](200, 56).IsErr())
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		assert.Equal(t, NewResValue(1.5), AddChecked(1.0, 0.5))
+		assert.True(t, AddChecked(math.MaxFloat64, math.MaxFloat64).IsErr())
+		assert.True(t, AddChecked(math.Inf(1), 1).IsVal())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		res := AddChecked[int8](100, 28)
+		assert.Equal(t, &OverflowError[int8]{V1: 100, V2: 28}, res.Err())
+		assert.Equal(t, "overflow adding '100' and '28' as int8", res.Err().Error())
+	})
+}
@@ -0,0 +1,142 @@
+package omap
+
+import "github.com/BennettJames/ef"
+
+type (
+	// OrderedMap is a map that remembers the order keys were first added in.
+	// Iterating it, whether with `Stream`, `Keys` or `Values`, always follows
+	// that order - unlike a regular map, where the order is random.
+	//
+	// The zero value is an empty map ready to use.
+	OrderedMap[K comparable, V any] struct {
+		entries    map[K]*entry[K, V]
+		head, tail *entry[K, V]
+	}
+
+	entry[K comparable, V any] struct {
+		key        K
+		val        V
+		prev, next *entry[K, V]
+	}
+)
+
+// New creates an empty ordered map.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		entries: make(map[K]*entry[K, V]),
+	}
+}
+
+// Put sets the value for the key. If the key is new, it is added to the end of
+// the map's order; if it already exists, its value is replaced but it keeps its
+// position.
+func (m *OrderedMap[K, V]) Put(key K, val V) {
+	if e, exists := m.entries[key]; exists {
+		e.val = val
+		return
+	}
+	e := &entry[K, V]{
+		key:  key,
+		val:  val,
+		prev: m.tail,
+	}
+	if m.tail != nil {
+		m.tail.next = e
+	} else {
+		m.head = e
+	}
+	m.tail = e
+	if m.entries == nil {
+		m.entries = make(map[K]*entry[K, V])
+	}
+	m.entries[key] = e
+}
+
+// Get returns the value for the key, or an empty optional if the key is not in
+// the map.
+func (m *OrderedMap[K, V]) Get(key K) ef.Opt[V] {
+	e, exists := m.entries[key]
+	if !exists {
+		return ef.Opt[V]{}
+	}
+	return ef.NewOptValue(e.val)
+}
+
+// Contains indicates if the key is in the map.
+func (m *OrderedMap[K, V]) Contains(key K) bool {
+	_, exists := m.entries[key]
+	return exists
+}
+
+// Delete removes the key from the map, and returns the value it had, or an
+// empty optional if the key was not in the map.
+func (m *OrderedMap[K, V]) Delete(key K) ef.Opt[V] {
+	e, exists := m.entries[key]
+	if !exists {
+		return ef.Opt[V]{}
+	}
+	delete(m.entries, key)
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		m.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		m.tail = e.prev
+	}
+	return ef.NewOptValue(e.val)
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys of the map, in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for e := m.head; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Values returns the values of the map, in the order of their keys.
+func (m *OrderedMap[K, V]) Values() []V {
+	vals := make([]V, 0, len(m.entries))
+	for e := m.head; e != nil; e = e.next {
+		vals = append(vals, e.val)
+	}
+	return vals
+}
+
+// Stream returns a stream of the key/value pairs of the map, in order.
+func (m *OrderedMap[K, V]) Stream() ef.Stream[ef.Pair[K, V]] {
+	return ef.NewStream[ef.Pair[K, V]](&ef.FnIter[ef.Pair[K, V]]{
+		Fn: func(opFn func(ef.Pair[K, V]) bool) {
+			for e := m.head; e != nil; e = e.next {
+				if !opFn(ef.PairOf(e.key, e.val)) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// Collect returns a collector that gathers pairs into an ordered map, in the
+// order they are collected. If a key appears more than once, its last value is
+// kept, at the position it first appeared.
+func Collect[K comparable, V any]() ef.Collector[ef.Pair[K, V], *OrderedMap[K, V], *OrderedMap[K, V]] {
+	return ef.Collector[ef.Pair[K, V], *OrderedMap[K, V], *OrderedMap[K, V]]{
+		Supply: New[K, V],
+		Accumulate: func(acc *OrderedMap[K, V], p ef.Pair[K, V]) *OrderedMap[K, V] {
+			acc.Put(p.Get())
+			return acc
+		},
+		Finish: func(acc *OrderedMap[K, V]) *OrderedMap[K, V] {
+			return acc
+		},
+	}
+}
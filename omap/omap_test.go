@@ -0,0 +1,137 @@
+package omap
+
+import (
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMapPut(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		m := New[string, int]()
+		m.Put("c", 1)
+		m.Put("a", 2)
+		m.Put("b", 3)
+		assert.Equal(t, ef.Slice("c", "a", "b"), m.Keys())
+		assert.Equal(t, ef.Slice(1, 2, 3), m.Values())
+		assert.Equal(t, 3, m.Len())
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		m := New[string, int]()
+		m.Put("a", 1)
+		m.Put("b", 2)
+		m.Put("a", 3)
+		assert.Equal(t, ef.Slice("a", "b"), m.Keys())
+		assert.Equal(t, ef.Slice(3, 2), m.Values())
+	})
+}
+
+func TestOrderedMapZero(t *testing.T) {
+	var m OrderedMap[string, int]
+	assert.Equal(t, ef.Opt[int]{}, m.Get("a"))
+	assert.Equal(t, ef.Opt[int]{}, m.Delete("a"))
+	m.Put("a", 1)
+	m.Put("b", 2)
+	assert.Equal(t, ef.NewOptValue(1), m.Get("a"))
+	assert.Equal(t, ef.Slice("a", "b"), m.Keys())
+}
+
+func TestOrderedMapGet(t *testing.T) {
+	m := New[string, int]()
+	m.Put("a", 1)
+	assert.Equal(t, ef.NewOptValue(1), m.Get("a"))
+	assert.Equal(t, ef.Opt[int]{}, m.Get("b"))
+	assert.True(t, m.Contains("a"))
+	assert.False(t, m.Contains("b"))
+}
+
+func TestOrderedMapDelete(t *testing.T) {
+	newMap := func() *OrderedMap[string, int] {
+		m := New[string, int]()
+		m.Put("a", 1)
+		m.Put("b", 2)
+		m.Put("c", 3)
+		return m
+	}
+
+	t.Run("Middle", func(t *testing.T) {
+		m := newMap()
+		assert.Equal(t, ef.NewOptValue(2), m.Delete("b"))
+		assert.Equal(t, ef.Slice("a", "c"), m.Keys())
+	})
+
+	t.Run("Ends", func(t *testing.T) {
+		m := newMap()
+		m.Delete("a")
+		m.Delete("c")
+		assert.Equal(t, ef.Slice("b"), m.Keys())
+		m.Put("d", 4)
+		assert.Equal(t, ef.Slice("b", "d"), m.Keys())
+	})
+
+	t.Run("All", func(t *testing.T) {
+		m := newMap()
+		m.Delete("b")
+		m.Delete("a")
+		m.Delete("c")
+		assert.Equal(t, ef.Slice[string](), m.Keys())
+		assert.Equal(t, 0, m.Len())
+		m.Put("d", 4)
+		assert.Equal(t, ef.Slice("d"), m.Keys())
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		m := newMap()
+		assert.Equal(t, ef.Opt[int]{}, m.Delete("z"))
+		assert.Equal(t, 3, m.Len())
+	})
+
+	t.Run("ReAdd", func(t *testing.T) {
+		m := newMap()
+		m.Delete("a")
+		m.Put("a", 5)
+		assert.Equal(t, ef.Slice("b", "c", "a"), m.Keys())
+	})
+}
+
+func TestOrderedMapStream(t *testing.T) {
+	m := New[int, string]()
+	for i, v := range ef.Slice("e", "d", "c", "b", "a") {
+		m.Put(i, v)
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(
+				ef.PairOf(0, "e"),
+				ef.PairOf(1, "d"),
+				ef.PairOf(2, "c"),
+				ef.PairOf(3, "b"),
+				ef.PairOf(4, "a"),
+			),
+			m.Stream().ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		readVals := ef.Slice[string]()
+		m.Stream().ExitableEach(func(p ef.Pair[int, string]) bool {
+			readVals = append(readVals, p.Second)
+			return len(readVals) < 2
+		})
+		assert.Equal(t, ef.Slice("e", "d"), readVals)
+	})
+}
+
+func TestOrderedMapCollect(t *testing.T) {
+	c := Collect[string, int]()
+	acc := c.Supply()
+	for _, p := range ef.Slice(ef.PairOf("b", 1), ef.PairOf("a", 2), ef.PairOf("b", 3)) {
+		acc = c.Accumulate(acc, p)
+	}
+	m := c.Finish(acc)
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("b", 3), ef.PairOf("a", 2)),
+		m.Stream().ToSlice())
+}
@@ -29,3 +29,8 @@ func (p Pair[T1, T2]) Get() (T1, T2) {
 func (p Pair[T1, T2]) String() string {
 	return fmt.Sprintf("(`%v`, `%v`)", p.First, p.Second)
 }
+
+// Swap returns a pair of the same values in the opposite order.
+func (p Pair[T1, T2]) Swap() Pair[T2, T1] {
+	return PairOf(p.Second, p.First)
+}
@@ -28,6 +28,10 @@ func TestPair(t *testing.T) {
 	t.Run("String", func(t *testing.T) {
 		assert.Equal(t, "(`hello`, `22`)", PairOf("hello", 22).String())
 	})
+
+	t.Run("Swap", func(t *testing.T) {
+		assert.Equal(t, PairOf(22, "hello"), PairOf("hello", 22).Swap())
+	})
 }
 
 func TestSize(t *testing.T) {
@@ -0,0 +1,66 @@
+package ef
+
+import (
+	"iter"
+	"runtime"
+)
+
+type (
+	// Puller is a pull-style iterator over a stream. Where a stream pushes each
+	// value to an operator, a puller yields a value each time `Next` is called.
+	// This makes it possible to consume several streams in lockstep, or to stop
+	// partway through a stream and resume later.
+	//
+	// A puller must be stopped with `Stop` once it is no longer needed, which
+	// runs any cleanup in the stream - e.g. its deferred calls. If it is
+	// abandoned without being stopped, a finalizer stops it once it is garbage
+	// collected, but this is only a last resort: the cleanup then runs on the
+	// runtime's single finalizer goroutine, where anything that blocks stalls
+	// every other finalizer in the process.
+	Puller[T any] struct {
+		next func() (T, bool)
+		stop func()
+	}
+)
+
+// Pull converts the stream into a pull-style iterator. The puller must be
+// stopped once it is no longer needed; see `Puller`.
+//
+// Example:
+//
+//	p := stream.OfVals(1, 2, 3).Pull()
+//	defer p.Stop()
+//	for v := p.Next(); v.HasVal(); v = p.Next() {
+//	  fmt.Println("value is - ", v.UnsafeGet())
+//	}
+func (s Stream[V]) Pull() *Puller[V] {
+	next, stop := iter.Pull(s.Seq())
+	p := &Puller[V]{
+		next: next,
+		stop: stop,
+	}
+	// the finalizer is just a safety net for abandoned pullers; Stop clears it.
+	// It's safe to call stop more than once.
+	runtime.SetFinalizer(p, func(p *Puller[V]) {
+		p.stop()
+	})
+	return p
+}
+
+// Next returns the next value in the stream, or an empty optional once the
+// stream is exhausted or the puller has been stopped.
+func (p *Puller[T]) Next() Opt[T] {
+	val, ok := p.next()
+	if !ok {
+		return Opt[T]{}
+	}
+	return NewOptValue(val)
+}
+
+// Stop ends iteration early. Any later calls to `Next` return an empty
+// optional. It is safe to call Stop more than once, or after the stream has
+// been exhausted.
+func (p *Puller[T]) Stop() {
+	runtime.SetFinalizer(p, nil)
+	p.stop()
+}
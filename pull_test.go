@@ -0,0 +1,60 @@
+package ef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPuller(t *testing.T) {
+	t.Run("Next", func(t *testing.T) {
+		p := streamOfSlice(Slice(1, 2)).Pull()
+		defer p.Stop()
+		assert.Equal(t, NewOptValue(1), p.Next())
+		assert.Equal(t, NewOptValue(2), p.Next())
+		assert.Equal(t, Opt[int]{}, p.Next())
+		assert.Equal(t, Opt[int]{}, p.Next())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		p := streamOfSlice(Slice[int]()).Pull()
+		defer p.Stop()
+		assert.Equal(t, Opt[int]{}, p.Next())
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		readCount := 0
+		st := NewStream[int](&FnIter[int]{
+			Fn: func(opFn func(int) bool) {
+				for i := 0; i < 10; i++ {
+					readCount++
+					if !opFn(i) {
+						return
+					}
+				}
+			},
+		})
+		p := st.Pull()
+		assert.Equal(t, NewOptValue(0), p.Next())
+		p.Stop()
+		p.Stop()
+		assert.Equal(t, Opt[int]{}, p.Next())
+		assert.Equal(t, 1, readCount)
+	})
+
+	t.Run("Lockstep", func(t *testing.T) {
+		p1 := streamOfSlice(Slice("a", "b", "c")).Pull()
+		defer p1.Stop()
+		p2 := streamOfSlice(Slice(1, 2)).Pull()
+		defer p2.Stop()
+		pairs := Slice[Pair[string, int]]()
+		for {
+			v1, v2 := p1.Next(), p2.Next()
+			if v1.IsEmpty() || v2.IsEmpty() {
+				break
+			}
+			pairs = append(pairs, PairOf(v1.UnsafeGet(), v2.UnsafeGet()))
+		}
+		assert.Equal(t, Slice(PairOf("a", 1), PairOf("b", 2)), pairs)
+	})
+}
@@ -31,6 +31,16 @@ func Of2[T, U any](v1 T, v2 U, e error) ef.Res[ef.Pair[T, U]] {
 	return Err[ef.Pair[T, U]](e)
 }
 
+// Of3 creates a result from four values, where the first three are turned into
+// a triple with the values stored. Like Of2, this is mostly for wrapping
+// functions that return three values and an error.
+func Of3[T, U, V any](v1 T, v2 U, v3 V, e error) ef.Res[ef.Triple[T, U, V]] {
+	if e == nil {
+		return Val(ef.TripleOf(v1, v2, v3))
+	}
+	return Err[ef.Triple[T, U, V]](e)
+}
+
 // OfPtr takes a par of a pointer value and an error, and converts it to a a
 // result. If the error is nonnil, then the result is an error type with the
 // error stored. If the value is present, then the pointer's value is stored in
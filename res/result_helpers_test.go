@@ -36,6 +36,19 @@ func TestRes(t *testing.T) {
 		})
 	})
 
+	t.Run("Of3", func(t *testing.T) {
+		t.Run("Vals", func(t *testing.T) {
+			res := Of3("a", 22, true, nil)
+			assert.Equal(t, Val(ef.TripleOf("a", 22, true)), res)
+		})
+
+		t.Run("Err", func(t *testing.T) {
+			err := fmt.Errorf("error")
+			res := Of3("a", 22, true, err)
+			assert.Equal(t, Err[ef.Triple[string, int, bool]](err), res)
+		})
+	})
+
 	t.Run("OfPtr", func(t *testing.T) {
 		t.Run("Val", func(t *testing.T) {
 			val, err := OfPtr(ef.Ptr("value"), nil).Get()
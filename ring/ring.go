@@ -0,0 +1,133 @@
+package ring
+
+import "github.com/BennettJames/ef"
+
+type (
+	// Ring is a fixed-capacity buffer of the most recent values pushed to it.
+	// Once it is full, each push overwrites the oldest value.
+	//
+	// All its storage is allocated up front, so pushing and popping never
+	// allocate. Use New to create a ring; the zero value has no capacity, so
+	// holds nothing and hands every pushed value straight back.
+	Ring[T any] struct {
+		buf  []T
+		head int
+		size int
+	}
+)
+
+// New creates an empty ring that holds up to `capacity` values. The capacity
+// must be positive.
+func New[T any](capacity int) *Ring[T] {
+	ef.AssertMsgf(capacity > 0, "ring capacity must be positive - got %d", capacity)
+	return &Ring[T]{
+		buf: make([]T, capacity),
+	}
+}
+
+// Len returns the number of values in the ring.
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// Cap returns the most values the ring can hold.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Full indicates if the ring is at capacity, so the next push will overwrite
+// the oldest value.
+func (r *Ring[T]) Full() bool {
+	return r.size == len(r.buf)
+}
+
+// Push adds the value as the newest in the ring. If the ring was full, the
+// oldest value is overwritten and returned; otherwise an empty optional is.
+func (r *Ring[T]) Push(val T) ef.Opt[T] {
+	if len(r.buf) == 0 {
+		return ef.NewOptValue(val)
+	}
+	if r.size < len(r.buf) {
+		r.buf[r.index(r.size)] = val
+		r.size++
+		return ef.Opt[T]{}
+	}
+	oldest := r.buf[r.head]
+	r.buf[r.head] = val
+	r.head = r.index(1)
+	return ef.NewOptValue(oldest)
+}
+
+// Pop removes and returns the oldest value in the ring, or returns an empty
+// optional if the ring is empty.
+func (r *Ring[T]) Pop() ef.Opt[T] {
+	if r.size == 0 {
+		return ef.Opt[T]{}
+	}
+	var zero T
+	val := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = r.index(1)
+	r.size--
+	return ef.NewOptValue(val)
+}
+
+// Oldest returns the oldest value in the ring, or an empty optional if the ring
+// is empty.
+func (r *Ring[T]) Oldest() ef.Opt[T] {
+	return r.At(0)
+}
+
+// Newest returns the most recently pushed value in the ring, or an empty
+// optional if the ring is empty.
+func (r *Ring[T]) Newest() ef.Opt[T] {
+	return r.At(r.size - 1)
+}
+
+// At returns the value at position `i` counting from the oldest, or an empty
+// optional if `i` is out of range.
+func (r *Ring[T]) At(i int) ef.Opt[T] {
+	if i < 0 || i >= r.size {
+		return ef.Opt[T]{}
+	}
+	return ef.NewOptValue(r.buf[r.index(i)])
+}
+
+// Clear removes every value from the ring.
+func (r *Ring[T]) Clear() {
+	clear(r.buf)
+	r.head = 0
+	r.size = 0
+}
+
+// Stream returns a stream of the ring's values from oldest to newest.
+func (r *Ring[T]) Stream() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for i := 0; i < r.size; i++ {
+				if !opFn(r.buf[r.index(i)]) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// ReverseStream returns a stream of the ring's values from newest to oldest.
+func (r *Ring[T]) ReverseStream() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for i := r.size - 1; i >= 0; i-- {
+				if !opFn(r.buf[r.index(i)]) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// index converts a position relative to the oldest value into an index in the
+// buffer.
+func (r *Ring[T]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
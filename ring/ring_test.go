@@ -0,0 +1,102 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingPush(t *testing.T) {
+	r := New[int](3)
+	assert.Equal(t, ef.Opt[int]{}, r.Push(1))
+	assert.Equal(t, ef.Opt[int]{}, r.Push(2))
+	assert.False(t, r.Full())
+	assert.Equal(t, ef.Opt[int]{}, r.Push(3))
+	assert.True(t, r.Full())
+	assert.Equal(t, ef.NewOptValue(1), r.Push(4))
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, 3, r.Cap())
+	assert.Equal(t, ef.NewOptValue(2), r.Oldest())
+	assert.Equal(t, ef.NewOptValue(4), r.Newest())
+}
+
+func TestRingPop(t *testing.T) {
+	r := New[int](2)
+	r.Push(1)
+	r.Push(2)
+	r.Push(3)
+	assert.Equal(t, ef.NewOptValue(2), r.Pop())
+	r.Push(4)
+	assert.Equal(t, ef.NewOptValue(3), r.Pop())
+	assert.Equal(t, ef.NewOptValue(4), r.Pop())
+	assert.Equal(t, ef.Opt[int]{}, r.Pop())
+	assert.Equal(t, ef.Opt[int]{}, r.Oldest())
+	assert.Equal(t, ef.Opt[int]{}, r.Newest())
+}
+
+func TestRingAt(t *testing.T) {
+	r := New[string](2)
+	r.Push("a")
+	r.Push("b")
+	r.Push("c")
+	assert.Equal(t, ef.NewOptValue("b"), r.At(0))
+	assert.Equal(t, ef.NewOptValue("c"), r.At(1))
+	assert.Equal(t, ef.Opt[string]{}, r.At(2))
+	assert.Equal(t, ef.Opt[string]{}, r.At(-1))
+}
+
+func TestRingClear(t *testing.T) {
+	r := New[int](2)
+	r.Push(1)
+	r.Push(2)
+	r.Push(3)
+	r.Clear()
+	assert.Equal(t, 0, r.Len())
+	r.Push(4)
+	assert.Equal(t, ef.Slice(4), r.Stream().ToSlice())
+}
+
+func TestRingStream(t *testing.T) {
+	r := New[int](3)
+	for i := 1; i <= 5; i++ {
+		r.Push(i)
+	}
+	assert.Equal(t, ef.Slice(3, 4, 5), r.Stream().ToSlice())
+	assert.Equal(t, ef.Slice(5, 4, 3), r.ReverseStream().ToSlice())
+
+	vals := make([]int, 0)
+	r.ReverseStream().ExitableEach(func(v int) bool {
+		vals = append(vals, v)
+		return false
+	})
+	assert.Equal(t, ef.Slice(5), vals)
+}
+
+func TestRingNew(t *testing.T) {
+	assert.Panics(t, func() { New[int](0) })
+}
+
+func TestRingZero(t *testing.T) {
+	var r Ring[int]
+	assert.Equal(t, ef.NewOptValue(1), r.Push(1))
+	assert.Equal(t, 0, r.Len())
+	assert.Equal(t, 0, r.Cap())
+	assert.True(t, r.Full())
+	assert.Equal(t, ef.Opt[int]{}, r.Pop())
+	assert.Equal(t, ef.Opt[int]{}, r.Oldest())
+	assert.Equal(t, ef.Opt[int]{}, r.Newest())
+	assert.Equal(t, ef.Slice[int](), r.Stream().ToSlice())
+	r.Clear()
+}
+
+func TestRingAllocs(t *testing.T) {
+	r := New[int](8)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 20; i++ {
+			r.Push(i)
+		}
+		r.Pop()
+	})
+	assert.Equal(t, 0.0, allocs)
+}
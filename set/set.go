@@ -0,0 +1,167 @@
+package set
+
+import "github.com/BennettJames/ef"
+
+type (
+	// Set is an unordered collection of unique values.
+	//
+	// A set is a map underneath, so the zero value is a nil set that can be read
+	// but not added to - use `Of` to create one.
+	Set[T comparable] map[T]struct{}
+)
+
+// Of creates a set containing the given values.
+func Of[T comparable](vals ...T) Set[T] {
+	s := make(Set[T], len(vals))
+	s.Add(vals...)
+	return s
+}
+
+// Add adds the given values to the set.
+func (s Set[T]) Add(vals ...T) {
+	for _, v := range vals {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove removes the given values from the set. Values that aren't in the set
+// are ignored.
+func (s Set[T]) Remove(vals ...T) {
+	for _, v := range vals {
+		delete(s, v)
+	}
+}
+
+// Contains indicates if the value is in the set.
+//
+// This works well as a predicate in its own right, e.g. -
+//
+//	allowed := set.Of("a", "b")
+//	st := stream.StreamKeep(names, ef.And(allowed.Contains, ef.Not(blocked.Contains)))
+func (s Set[T]) Contains(val T) bool {
+	_, exists := s[val]
+	return exists
+}
+
+// Len returns the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	clone := make(Set[T], len(s))
+	for v := range s {
+		clone[v] = struct{}{}
+	}
+	return clone
+}
+
+// Union returns a new set of the values that are in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := s.Clone()
+	for v := range other {
+		union[v] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new set of the values that are in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	intersection := make(Set[T])
+	for v := range small {
+		if large.Contains(v) {
+			intersection[v] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set of the values that are in this set, but not the
+// other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	diff := make(Set[T])
+	for v := range s {
+		if !other.Contains(v) {
+			diff[v] = struct{}{}
+		}
+	}
+	return diff
+}
+
+// SymmetricDifference returns a new set of the values that are in exactly one
+// of the two sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	diff := s.Difference(other)
+	for v := range other {
+		if !s.Contains(v) {
+			diff[v] = struct{}{}
+		}
+	}
+	return diff
+}
+
+// IsSubset indicates if every value in this set is also in the other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset indicates if every value in the other set is also in this one.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal indicates if both sets have exactly the same values.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// ToSlice returns the values of the set as a slice, in no particular order.
+func (s Set[T]) ToSlice() []T {
+	vals := make([]T, 0, len(s))
+	for v := range s {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// Stream returns a stream of the values in the set, in no particular order.
+func (s Set[T]) Stream() ef.Stream[T] {
+	return ef.NewStream[T](&ef.FnIter[T]{
+		Fn: func(opFn func(T) bool) {
+			for v := range s {
+				if !opFn(v) {
+					return
+				}
+			}
+		},
+	})
+}
+
+// Collect returns a collector that gathers every value into a set.
+func Collect[T comparable]() ef.Collector[T, Set[T], Set[T]] {
+	return ef.Collector[T, Set[T], Set[T]]{
+		Supply: func() Set[T] {
+			return Of[T]()
+		},
+		Accumulate: func(acc Set[T], val T) Set[T] {
+			acc.Add(val)
+			return acc
+		},
+		Finish: func(acc Set[T]) Set[T] {
+			return acc
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/BennettJames/ef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOf(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		s := Of(1, 2, 2, 3)
+		assert.Equal(t, 3, s.Len())
+		assert.Equal(t, Set[int]{1: {}, 2: {}, 3: {}}, s)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		s := Of[int]()
+		assert.Equal(t, 0, s.Len())
+		s.Add(1)
+		assert.True(t, s.Contains(1))
+	})
+}
+
+func TestSetAddRemove(t *testing.T) {
+	s := Of[string]()
+	s.Add("a", "b")
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.Contains("b"))
+	assert.False(t, s.Contains("c"))
+
+	s.Remove("a", "c")
+	assert.False(t, s.Contains("a"))
+	assert.Equal(t, Of("b"), s)
+}
+
+func TestSetNil(t *testing.T) {
+	var s Set[int]
+	assert.False(t, s.Contains(1))
+	assert.Equal(t, 0, s.Len())
+	assert.True(t, s.IsSubset(Of(1)))
+	assert.Equal(t, Of(1), s.Union(Of(1)))
+}
+
+func TestSetClone(t *testing.T) {
+	s := Of(1, 2)
+	clone := s.Clone()
+	clone.Add(3)
+	assert.Equal(t, Of(1, 2), s)
+	assert.Equal(t, Of(1, 2, 3), clone)
+}
+
+func TestSetOps(t *testing.T) {
+	a, b := Of(1, 2, 3), Of(2, 3, 4)
+
+	t.Run("Union", func(t *testing.T) {
+		assert.Equal(t, Of(1, 2, 3, 4), a.Union(b))
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		assert.Equal(t, Of(2, 3), a.Intersection(b))
+		assert.Equal(t, Of[int](), a.Intersection(Of(5)))
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		assert.Equal(t, Of(1), a.Difference(b))
+		assert.Equal(t, Of(4), b.Difference(a))
+	})
+
+	t.Run("SymmetricDifference", func(t *testing.T) {
+		assert.Equal(t, Of(1, 4), a.SymmetricDifference(b))
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, Of(1, 2, 3), a)
+		assert.Equal(t, Of(2, 3, 4), b)
+	})
+}
+
+func TestSetSubset(t *testing.T) {
+	assert.True(t, Of(1, 2).IsSubset(Of(1, 2, 3)))
+	assert.True(t, Of(1, 2).IsSubset(Of(1, 2)))
+	assert.False(t, Of(1, 4).IsSubset(Of(1, 2, 3)))
+	assert.True(t, Of(1, 2, 3).IsSuperset(Of(1, 2)))
+	assert.False(t, Of(1, 2).IsSuperset(Of(1, 2, 3)))
+}
+
+func TestSetEqual(t *testing.T) {
+	assert.True(t, Of(1, 2).Equal(Of(2, 1)))
+	assert.False(t, Of(1, 2).Equal(Of(1, 2, 3)))
+	assert.False(t, Of(1, 2).Equal(Of(1, 3)))
+}
+
+func TestSetToSlice(t *testing.T) {
+	vals := Of(3, 1, 2).ToSlice()
+	slices.Sort(vals)
+	assert.Equal(t, ef.Slice(1, 2, 3), vals)
+}
+
+func TestSetStream(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		vals := Of("a", "b", "c").Stream().ToSlice()
+		slices.Sort(vals)
+		assert.Equal(t, ef.Slice("a", "b", "c"), vals)
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		readCount := 0
+		Of(1, 2, 3).Stream().ExitableEach(func(int) bool {
+			readCount++
+			return false
+		})
+		assert.Equal(t, 1, readCount)
+	})
+}
+
+func TestSetPredicates(t *testing.T) {
+	allowed, blocked := Of(1, 2, 3, 4), Of(2, 5)
+	check := ef.And(allowed.Contains, ef.Not(blocked.Contains))
+	assert.True(t, check(1))
+	assert.False(t, check(2))
+	assert.False(t, check(5))
+
+	either := ef.Or(allowed.Contains, blocked.Contains)
+	assert.True(t, either(5))
+	assert.False(t, either(6))
+}
+
+func TestSetCollect(t *testing.T) {
+	c := Collect[int]()
+	acc := c.Supply()
+	for _, v := range ef.Slice(1, 2, 3, 2) {
+		acc = c.Accumulate(acc, v)
+	}
+	assert.Equal(t, Of(1, 2, 3), c.Finish(acc))
+	assert.Equal(t, Of[int](), c.Finish(c.Supply()))
+}
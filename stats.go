@@ -0,0 +1,294 @@
+package ef
+
+import (
+	"math"
+	"math/big"
+	"slices"
+)
+
+type (
+	// WideSummaryStats is as SummaryStats, but accumulates the total of the
+	// values as an arbitrary-precision integer so it cannot overflow. Min and Max
+	// are still reported in the type of the values.
+	WideSummaryStats[N Integer] struct {
+		Average  float64
+		Size     int
+		Total    *big.Int
+		Min, Max Opt[N]
+	}
+
+	// SummaryStatsExt extends SummaryStats with the variance and standard
+	// deviation of the values. Values are added one at a time with `Add`, so
+	// the stats can be calculated in a single pass without holding the values
+	// in memory.
+	//
+	// The variance is calculated with Welford's online algorithm, and for float
+	// types the total uses compensated summation; both avoid the error that
+	// builds up over long streams with naive sums.
+	SummaryStatsExt[N Number] struct {
+		SummaryStats[N]
+
+		// Variance is the population variance of the values.
+		Variance float64
+
+		// SampleVariance is the sample variance (with Bessel's correction) of the
+		// values. It is 0 for fewer than two values.
+		SampleVariance float64
+
+		// StdDev is the population standard deviation of the values.
+		StdDev float64
+
+		m2           float64
+		sum, sumComp float64
+	}
+
+	// QuantileSketch estimates a single quantile of a set of values in constant
+	// memory, using the P² algorithm. This is useful for streams that are too
+	// large to buffer; for exact results use `collect.Percentile`.
+	//
+	// Estimates are exact for up to five values, and generally close after
+	// that - though the algorithm can be thrown off by adversarial orderings.
+	QuantileSketch struct {
+		p     float64
+		count int
+
+		// these are the marker heights, actual positions, desired
+		// positions and desired position increments as named in the paper.
+		q, n, np, dn [5]float64
+	}
+)
+
+// Add returns the stats updated with the given value.
+func (s SummaryStats[N]) Add(v N) SummaryStats[N] {
+	s.Total += v
+	return s.observe(v)
+}
+
+// AddChecked returns the stats updated with the given value, or an error
+// result with an `*OverflowError` if adding it would overflow the total.
+func (s SummaryStats[N]) AddChecked(v N) Res[SummaryStats[N]] {
+	total := AddChecked(s.Total, v)
+	if total.IsErr() {
+		return NewResError[SummaryStats[N]](total.Err())
+	}
+	s.Total = total.Val()
+	return NewResValue(s.observe(v))
+}
+
+func (s SummaryStats[N]) observe(v N) SummaryStats[N] {
+	s.Size++
+	s.Min, s.Max = minOpt(s.Min, v), maxOpt(s.Max, v)
+	s.Average = float64(s.Total) / float64(s.Size)
+	return s
+}
+
+// NewWideSummaryStats creates an empty WideSummaryStats.
+func NewWideSummaryStats[N Integer]() *WideSummaryStats[N] {
+	return &WideSummaryStats[N]{
+		Total: new(big.Int),
+	}
+}
+
+// Add updates the stats with the given value.
+func (s *WideSummaryStats[N]) Add(v N) {
+	if isSigned[N]() {
+		s.Total.Add(s.Total, big.NewInt(int64(v)))
+	} else {
+		s.Total.Add(s.Total, new(big.Int).SetUint64(uint64(v)))
+	}
+	s.Size++
+	s.Min, s.Max = minOpt(s.Min, v), maxOpt(s.Max, v)
+	s.Average += (float64(v) - s.Average) / float64(s.Size)
+}
+
+// NewSummaryStatsExt creates an empty SummaryStatsExt.
+func NewSummaryStatsExt[N Number]() SummaryStatsExt[N] {
+	return SummaryStatsExt[N]{}
+}
+
+// Add returns the stats updated with the given value.
+func (s SummaryStatsExt[N]) Add(v N) SummaryStatsExt[N] {
+	s.Size++
+	s.Min, s.Max = minOpt(s.Min, v), maxOpt(s.Max, v)
+
+	fv := float64(v)
+	if isFloat[N]() {
+		// this is Neumaier's variant of Kahan summation, which also
+		// handles values larger than the running sum.
+		t := s.sum + fv
+		if math.Abs(s.sum) >= math.Abs(fv) {
+			s.sumComp += (s.sum - t) + fv
+		} else {
+			s.sumComp += (fv - t) + s.sum
+		}
+		s.sum = t
+		s.Total = N(s.sum + s.sumComp)
+	} else {
+		s.Total += v
+	}
+
+	delta := fv - s.Average
+	s.Average += delta / float64(s.Size)
+	s.m2 += delta * (fv - s.Average)
+
+	s.Variance = s.m2 / float64(s.Size)
+	s.StdDev = math.Sqrt(s.Variance)
+	if s.Size > 1 {
+		s.SampleVariance = s.m2 / float64(s.Size-1)
+	}
+	return s
+}
+
+// NewQuantileSketch creates a sketch that estimates the given percentile, which
+// must be between 0 and 100.
+func NewQuantileSketch(percentile float64) *QuantileSketch {
+	assertPercentile(percentile)
+	p := percentile / 100
+	return &QuantileSketch{
+		p:  p,
+		n:  [5]float64{0, 1, 2, 3, 4},
+		np: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		dn: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+// Add adds a value to the sketch.
+func (qs *QuantileSketch) Add(v float64) {
+	if qs.count < 5 {
+		qs.q[qs.count] = v
+		qs.count++
+		if qs.count == 5 {
+			slices.Sort(qs.q[:])
+		}
+		return
+	}
+	qs.count++
+
+	var k int
+	switch {
+	case v < qs.q[0]:
+		qs.q[0] = v
+		k = 0
+	case v < qs.q[1]:
+		k = 0
+	case v < qs.q[2]:
+		k = 1
+	case v < qs.q[3]:
+		k = 2
+	case v <= qs.q[4]:
+		k = 3
+	default:
+		qs.q[4] = v
+		k = 3
+	}
+	for i := k + 1; i < 5; i++ {
+		qs.n[i]++
+	}
+	for i := range qs.np {
+		qs.np[i] += qs.dn[i]
+	}
+
+	for i := 1; i <= 3; i++ {
+		d := qs.np[i] - qs.n[i]
+		if (d >= 1 && qs.n[i+1]-qs.n[i] > 1) || (d <= -1 && qs.n[i-1]-qs.n[i] < -1) {
+			ds := math.Copysign(1, d)
+			if qp := qs.parabolic(i, ds); qs.q[i-1] < qp && qp < qs.q[i+1] {
+				qs.q[i] = qp
+			} else {
+				qs.q[i] = qs.linear(i, ds)
+			}
+			qs.n[i] += ds
+		}
+	}
+}
+
+// Value returns the current estimate of the percentile, or an empty optional if
+// no values have been added.
+func (qs *QuantileSketch) Value() Opt[float64] {
+	if qs.count == 0 {
+		return Opt[float64]{}
+	}
+	if qs.count < 5 {
+		vals := slices.Clone(qs.q[:qs.count])
+		slices.Sort(vals)
+		return NewOptValue(interpolatePercentile(vals, qs.p))
+	}
+	// the outer markers always hold the exact min and max, which are better
+	// than the middle marker's estimate for the extremes.
+	switch qs.p {
+	case 0:
+		return NewOptValue(qs.q[0])
+	case 1:
+		return NewOptValue(qs.q[4])
+	}
+	return NewOptValue(qs.q[2])
+}
+
+// Count returns the number of values that have been added to the sketch.
+func (qs *QuantileSketch) Count() int {
+	return qs.count
+}
+
+func (qs *QuantileSketch) parabolic(i int, d float64) float64 {
+	q, n := qs.q, qs.n
+	return q[i] + d/(n[i+1]-n[i-1])*
+		((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+			(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+func (qs *QuantileSketch) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return qs.q[i] + d*(qs.q[j]-qs.q[i])/(qs.n[j]-qs.n[i])
+}
+
+// Percentile returns the given percentile (between 0 and 100) of a sorted
+// slice of values, interpolating linearly between the closest ranks. Returns
+// an empty optional if the slice is empty.
+func Percentile(sorted []float64, percentile float64) Opt[float64] {
+	assertPercentile(percentile)
+	if len(sorted) == 0 {
+		return Opt[float64]{}
+	}
+	return NewOptValue(interpolatePercentile(sorted, percentile/100))
+}
+
+// assertPercentile panics if the percentile is not between 0 and 100.
+func assertPercentile(percentile float64) {
+	AssertMsgf(
+		percentile >= 0 && percentile <= 100,
+		"percentile must be between 0 and 100, got %v", percentile)
+}
+
+func interpolatePercentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (rank-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+func minOpt[N Number](o Opt[N], v N) Opt[N] {
+	if o.present && o.value <= v {
+		return o
+	}
+	return NewOptValue(v)
+}
+
+func maxOpt[N Number](o Opt[N], v N) Opt[N] {
+	if o.present && o.value >= v {
+		return o
+	}
+	return NewOptValue(v)
+}
+
+func isSigned[N Integer]() bool {
+	return N(0)-1 < 0
+}
+
+func isFloat[N Number]() bool {
+	switch any(*new(N)).(type) {
+	case float32, float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,199 @@
+package ef
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryStatsExt(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := NewSummaryStatsExt[int]()
+		assert.Equal(t, 0, s.Size)
+		assert.Equal(t, 0.0, s.Average)
+		assert.Equal(t, 0.0, s.Variance)
+		assert.Equal(t, 0.0, s.StdDev)
+	})
+
+	t.Run("Int", func(t *testing.T) {
+		s := NewSummaryStatsExt[int]()
+		for _, v := range Slice(2, 4, 4, 4, 5, 5, 7, 9) {
+			s = s.Add(v)
+		}
+		assert.Equal(t, SummaryStats[int]{
+			Average: 5,
+			Size:    8,
+			Total:   40,
+			Min:     NewOptValue(2),
+			Max:     NewOptValue(9),
+		}, s.SummaryStats)
+		assert.Equal(t, 4.0, s.Variance)
+		assert.Equal(t, 2.0, s.StdDev)
+		assert.InDelta(t, 32.0/7, s.SampleVariance, 1e-12)
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		s := NewSummaryStatsExt[float64]().Add(3.5)
+		assert.Equal(t, 3.5, s.Average)
+		assert.Equal(t, 0.0, s.Variance)
+		assert.Equal(t, 0.0, s.SampleVariance)
+	})
+
+	t.Run("LargeOffset", func(t *testing.T) {
+		// the naive sum-of-squares formula loses all precision here.
+		s := NewSummaryStatsExt[float64]()
+		for _, v := range Slice(4.0, 7.0, 13.0, 16.0) {
+			s = s.Add(1e9 + v)
+		}
+		assert.InDelta(t, 22.5, s.Variance, 1e-6)
+	})
+
+	t.Run("CompensatedTotal", func(t *testing.T) {
+		s := NewSummaryStatsExt[float64]()
+		naive := 0.0
+		for i := 0; i < 1_000_000; i++ {
+			s = s.Add(0.1)
+			naive += 0.1
+		}
+		assert.NotEqual(t, 100_000.0, naive)
+		assert.Equal(t, 100_000.0, s.Total)
+	})
+}
+
+func TestSummaryStats(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := SummaryStats[int]{}
+		assert.True(t, s.Min.IsEmpty())
+		assert.True(t, s.Max.IsEmpty())
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		s := SummaryStats[int]{}.Add(3).Add(-1).Add(4)
+		assert.Equal(t, SummaryStats[int]{
+			Average: 2,
+			Size:    3,
+			Total:   6,
+			Min:     NewOptValue(-1),
+			Max:     NewOptValue(4),
+		}, s)
+	})
+
+	t.Run("AddChecked", func(t *testing.T) {
+		s := SummaryStats[int8]{}.AddChecked(100)
+		assert.Equal(t, NewResValue(SummaryStats[int8]{
+			Average: 100,
+			Size:    1,
+			Total:   100,
+			Min:     NewOptValue[int8](100),
+			Max:     NewOptValue[int8](100),
+		}), s)
+
+		s = s.Val().AddChecked(100)
+		assert.True(t, s.IsErr())
+		var overflowErr *OverflowError[int8]
+		assert.ErrorAs(t, s.Err(), &overflowErr)
+	})
+}
+
+func TestWideSummaryStats(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := NewWideSummaryStats[int8]()
+		assert.Equal(t, 0, s.Size)
+		assert.Equal(t, int64(0), s.Total.Int64())
+		assert.True(t, s.Min.IsEmpty())
+		assert.True(t, s.Max.IsEmpty())
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		s := NewWideSummaryStats[int8]()
+		for _, v := range Slice[int8](100, 120, -50, 127) {
+			s.Add(v)
+		}
+		assert.Equal(t, 4, s.Size)
+		assert.Equal(t, int64(297), s.Total.Int64())
+		assert.Equal(t, 74.25, s.Average)
+		assert.Equal(t, NewOptValue[int8](-50), s.Min)
+		assert.Equal(t, NewOptValue[int8](127), s.Max)
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		s := NewWideSummaryStats[uint64]()
+		s.Add(math.MaxUint64)
+		s.Add(math.MaxUint64)
+		assert.Equal(t, "36893488147419103230", s.Total.String())
+		assert.Equal(t, float64(math.MaxUint64), s.Average)
+	})
+}
+
+func TestQuantileSketch(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, Opt[float64]{}, NewQuantileSketch(50).Value())
+	})
+
+	t.Run("Small", func(t *testing.T) {
+		qs := NewQuantileSketch(50)
+		for _, v := range Slice(3.0, 1.0, 2.0, 4.0) {
+			qs.Add(v)
+		}
+		assert.Equal(t, NewOptValue(2.5), qs.Value())
+		assert.Equal(t, 4, qs.Count())
+	})
+
+	t.Run("Uniform", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, p := range Slice(10.0, 50.0, 90.0) {
+			qs := NewQuantileSketch(p)
+			for i := 0; i < 100_000; i++ {
+				qs.Add(r.Float64() * 1000)
+			}
+			assert.InDelta(t, p*10, qs.Value().UnsafeGet(), 10)
+		}
+	})
+
+	t.Run("Extremes", func(t *testing.T) {
+		r := rand.New(rand.NewSource(3))
+		minQs, maxQs := NewQuantileSketch(0), NewQuantileSketch(100)
+		vals := make([]float64, 0, 1000)
+		for i := 0; i < 1000; i++ {
+			v := r.Float64() * 1000
+			vals = append(vals, v)
+			minQs.Add(v)
+			maxQs.Add(v)
+		}
+		assert.Equal(t, NewOptValue(slices.Min(vals)), minQs.Value())
+		assert.Equal(t, NewOptValue(slices.Max(vals)), maxQs.Value())
+	})
+
+	t.Run("Normal", func(t *testing.T) {
+		r := rand.New(rand.NewSource(2))
+		qs := NewQuantileSketch(50)
+		vals := make([]float64, 0, 50_000)
+		for i := 0; i < 50_000; i++ {
+			v := r.NormFloat64()*5 + 100
+			vals = append(vals, v)
+			qs.Add(v)
+		}
+		slices.Sort(vals)
+		exact := Percentile(vals, 50).UnsafeGet()
+		assert.InDelta(t, exact, qs.Value().UnsafeGet(), 0.1)
+	})
+
+	t.Run("BadPercentile", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewQuantileSketch(101)
+		})
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	vals := Slice(1.0, 2.0, 3.0, 4.0, 5.0)
+	assert.Equal(t, NewOptValue(1.0), Percentile(vals, 0))
+	assert.Equal(t, NewOptValue(3.0), Percentile(vals, 50))
+	assert.Equal(t, NewOptValue(5.0), Percentile(vals, 100))
+	assert.Equal(t, NewOptValue(4.6), Percentile(vals, 90))
+	assert.Equal(t, Opt[float64]{}, Percentile(nil, 50))
+	assert.False(t, math.IsNaN(Percentile(Slice(1.0), 50).UnsafeGet()))
+}
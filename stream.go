@@ -1,5 +1,7 @@
 package ef
 
+import "iter"
+
 type (
 	Stream[T any] struct {
 		srcIter Iter[T]
@@ -18,15 +20,30 @@ type (
 		~[]T | ~*T | Opt[T] | Stream[T]
 	}
 
+	// Collector describes how to gather the values of a stream into a single
+	// result. A fresh accumulator is created with `Supply`, each value is added
+	// to it with `Accumulate`, and the final result is produced from it with
+	// `Finish`.
+	//
+	// Collectors can be nested - e.g. a collector that groups values by key can
+	// be given another collector to gather the values of each group.
+	Collector[T, A, R any] struct {
+		Supply     func() A
+		Accumulate func(acc A, val T) A
+		Finish     func(acc A) R
+	}
+
 	// SummaryStats contains a set of data about the values in a stream of numbers.
+	// Min and Max are empty if there are no values.
 	//
 	// Note that this is not safe with overflow - if the sum exceeds the number
 	// type, then overflow will occur and total / average will not be accurate.
+	// Use `AddChecked` to detect overflow, or `WideSummaryStats` to avoid it.
 	SummaryStats[N Number] struct {
 		Average  float64
 		Size     int
 		Total    N
-		Min, Max N
+		Min, Max Opt[N]
 	}
 )
 
@@ -63,3 +80,30 @@ func (s Stream[V]) ToSlice() []V {
 	})
 	return l
 }
+
+// Seq converts the stream to a standard library iterator, so it can be used
+// directly in a `for range` loop.
+//
+// Example:
+//
+//	for v := range stream.OfVals(1, 2, 3).Seq() {
+//	  fmt.Println("value is - ", v)
+//	}
+//
+// Breaking out of the loop stops the stream just as returning false from
+// `ExitableEach` would.
+func (s Stream[V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		// range-over-func panics if yield is called again after it returns
+		// false. Guard against iterators that don't honor advance rather than
+		// let that surface as a panic in the caller's loop.
+		done := false
+		s.srcIter.Next(func(val V) (advance bool) {
+			if done {
+				return false
+			}
+			done = !yield(val)
+			return !done
+		})
+	}
+}
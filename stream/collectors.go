@@ -1,31 +1,36 @@
 package stream
 
 import (
-	"fmt"
-	"strings"
+	"cmp"
+	"context"
+	"errors"
 
 	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/collect"
+	"github.com/BennettJames/ef/omap"
+	"github.com/BennettJames/ef/set"
 )
 
 // ToMap takes each value in a pair-stream, and turns it into a map where the
 // keys are the first value in the pairs, and the values the second.
 //
 // Note that this cannot handle key collisions - if two pairs have the same `T`
-// value, this will panic. Use `StreamToMapMerge` to resolve collisions.
+// value, this will panic with a `*ef.DuplicateKeyError`. Use `ToMapMerge` to
+// resolve collisions, or `ToMapRes` to get them as an error.
 func ToMap[T comparable, U any](srcSt ef.Stream[ef.Pair[T, U]]) map[T]U {
-	m := make(map[T]U)
-	EachPair(srcSt, func(t T, u U) {
-		if existing, exists := m[t]; !exists {
-			m[t] = u
-		} else {
-			// todo [bs]: probably want a custom error for this
-			panic(fmt.Errorf(
-				"StreamToMap: duplicate values found for key '%v' - ['%v', '%v']",
-				t, u, existing))
-		}
-		m[t] = u
-	})
-	return m
+	return Collect(srcSt, collect.ToMap[T, U]())
+}
+
+// ToSet gathers the values of the stream into a set.
+func ToSet[T comparable](srcSt ef.Stream[T]) set.Set[T] {
+	return Collect(srcSt, set.Collect[T]())
+}
+
+// ToOrderedMap gathers a pair stream into an ordered map, which keeps the keys
+// in stream order. If a key appears more than once, its last value is kept, at
+// the position it first appeared.
+func ToOrderedMap[K comparable, V any](srcSt ef.Stream[ef.Pair[K, V]]) *omap.OrderedMap[K, V] {
+	return Collect(srcSt, omap.Collect[K, V]())
 }
 
 // ToMapMerge gathers a pair stream into a map, and resolves any duplicate keys
@@ -34,16 +39,55 @@ func ToMapMerge[T comparable, U any](
 	srcSt ef.Stream[ef.Pair[T, U]],
 	mergeOp func(key T, val1, val2 U) U,
 ) map[T]U {
+	return Collect(srcSt, collect.ToMapMerge(mergeOp))
+}
+
+// ToMapRes is as ToMap, but rather than panicking on a duplicate key it stops
+// and returns an error result. The error is a `*ef.DuplicateKeyError[T, U]`,
+// which holds the key and both its values.
+func ToMapRes[T comparable, U any](srcSt ef.Stream[ef.Pair[T, U]]) ef.Res[map[T]U] {
+	m := make(map[T]U)
+	var dupErr error
+	srcSt.ExitableEach(func(p ef.Pair[T, U]) bool {
+		if existing, exists := m[p.First]; exists {
+			dupErr = &ef.DuplicateKeyError[T, U]{
+				Key:       p.First,
+				Existing:  existing,
+				Duplicate: p.Second,
+			}
+			return false
+		}
+		m[p.First] = p.Second
+		return true
+	})
+	if dupErr != nil {
+		return ef.NewResError[map[T]U](dupErr)
+	}
+	return ef.NewResValue(m)
+}
+
+// ToMapResAll is as ToMapRes, but reads the entire stream and reports every
+// duplicate rather than just the first. The error joins a
+// `*ef.DuplicateKeyError[T, U]` for each duplicate, in stream order; each is
+// against the first value seen for the key.
+func ToMapResAll[T comparable, U any](srcSt ef.Stream[ef.Pair[T, U]]) ef.Res[map[T]U] {
 	m := make(map[T]U)
-	EachPair(srcSt, func(key T, value U) {
-		if existing, exists := m[key]; !exists {
-			m[key] = value
-		} else {
-			m[key] = mergeOp(key, existing, value)
+	var dupErrs []error
+	EachPair(srcSt, func(key T, val U) {
+		if existing, exists := m[key]; exists {
+			dupErrs = append(dupErrs, &ef.DuplicateKeyError[T, U]{
+				Key:       key,
+				Existing:  existing,
+				Duplicate: val,
+			})
+			return
 		}
-		m[key] = value
+		m[key] = val
 	})
-	return m
+	if len(dupErrs) > 0 {
+		return ef.NewResError[map[T]U](errors.Join(dupErrs...))
+	}
+	return ef.NewResValue(m)
 }
 
 // Reduce combines all the values in the stream down to one of type `U`. A value
@@ -78,10 +122,7 @@ func ReduceInit[T any, U any](
 	initVal U,
 	reduceOp func(total U, val T) U,
 ) U {
-	srcSt.Each(func(v T) {
-		initVal = reduceOp(initVal, v)
-	})
-	return initVal
+	return Collect(srcSt, collect.Reducing(initVal, reduceOp))
 }
 
 // Find searches the stream for a value that matches the provided find operator.
@@ -101,6 +142,96 @@ func Find[T any](
 	return foundVal
 }
 
+// First returns the first element of the stream, or an empty optional if the
+// stream is empty. Only the first element is read from the stream.
+func First[T any](srcSt ef.Stream[T]) ef.Opt[T] {
+	return Nth(srcSt, 0)
+}
+
+// Last returns the last element of the stream, or an empty optional if the
+// stream is empty.
+func Last[T any](srcSt ef.Stream[T]) ef.Opt[T] {
+	var last ef.Opt[T]
+	srcSt.Each(func(val T) {
+		last = ef.NewOptValue(val)
+	})
+	return last
+}
+
+// Nth returns the element at the given (zero-based) index in the stream, or an
+// empty optional if the stream isn't that long. The stream is not read past
+// the index.
+func Nth[T any](srcSt ef.Stream[T], n int) ef.Opt[T] {
+	if n < 0 {
+		return ef.Opt[T]{}
+	}
+	var nth ef.Opt[T]
+	index := 0
+	srcSt.ExitableEach(func(val T) bool {
+		if index == n {
+			nth = ef.NewOptValue(val)
+			return false
+		}
+		index++
+		return true
+	})
+	return nth
+}
+
+// MinBy returns the element of the stream with the lowest key, as returned from
+// `keyOp`, or an empty optional if the stream is empty. If several elements
+// share the lowest key, the first is returned.
+func MinBy[T any, K cmp.Ordered](srcSt ef.Stream[T], keyOp func(T) K) ef.Opt[T] {
+	return bestBy(srcSt, keyOp, func(key, bestKey K) bool {
+		return key < bestKey
+	})
+}
+
+// MaxBy returns the element of the stream with the highest key, as returned
+// from `keyOp`, or an empty optional if the stream is empty. If several
+// elements share the highest key, the first is returned.
+func MaxBy[T any, K cmp.Ordered](srcSt ef.Stream[T], keyOp func(T) K) ef.Opt[T] {
+	return bestBy(srcSt, keyOp, func(key, bestKey K) bool {
+		return key > bestKey
+	})
+}
+
+func bestBy[T any, K cmp.Ordered](
+	srcSt ef.Stream[T],
+	keyOp func(T) K,
+	betterOp func(key, bestKey K) bool,
+) ef.Opt[T] {
+	var best ef.Opt[T]
+	var bestKey K
+	srcSt.Each(func(val T) {
+		key := keyOp(val)
+		if best.IsEmpty() || betterOp(key, bestKey) {
+			best, bestKey = ef.NewOptValue(val), key
+		}
+	})
+	return best
+}
+
+// Single returns the only element of the stream. If the stream is empty or has
+// more than one element, an error result with an `*ef.NotSingleError` is
+// returned instead. The stream is not read past the second element.
+func Single[T any](srcSt ef.Stream[T]) ef.Res[T] {
+	var single ef.Opt[T]
+	multiple := false
+	srcSt.ExitableEach(func(val T) bool {
+		if single.HasVal() {
+			multiple = true
+			return false
+		}
+		single = ef.NewOptValue(val)
+		return true
+	})
+	if single.IsEmpty() || multiple {
+		return ef.NewResError[T](&ef.NotSingleError{Empty: single.IsEmpty()})
+	}
+	return ef.NewResValue(single.UnsafeGet())
+}
+
 // Match will return true if any element in the source stream passes the given
 // match operator, and false otherwise.
 func Match[T any](
@@ -137,36 +268,161 @@ func AllMatch[T any](
 
 // Stats calculates the SummaryStats object for a stream of numbers.
 func Stats[N ef.Number](srcSt ef.Stream[N]) ef.SummaryStats[N] {
-	// note [bs]: possible it'd just be better to make these optionals rather than
-	// have default vals for them.
-	stats := ef.SummaryStats[N]{
-		Min: ef.MaxNumber[N](),
-		Max: ef.MinNumber[N](),
-	}
-	srcSt.Each(func(v N) {
-		stats.Size++
-		stats.Total += v
-		stats.Min = ef.Min(stats.Min, v)
-		stats.Max = ef.Max(stats.Max, v)
+	return Collect(srcSt, collect.Stats[N]())
+}
+
+// StatsChecked calculates the SummaryStats object for a stream of numbers, or
+// returns an error result with an `*ef.OverflowError` if the total overflows the
+// number type. The stream is not read past the value that overflows.
+func StatsChecked[N ef.Number](srcSt ef.Stream[N]) ef.Res[ef.SummaryStats[N]] {
+	stats := ef.NewResValue(ef.SummaryStats[N]{})
+	srcSt.ExitableEach(func(v N) bool {
+		stats = stats.Val().AddChecked(v)
+		return stats.IsVal()
 	})
-	if stats.Size > 0 {
-		stats.Average = float64(stats.Total) / float64(stats.Size)
-	}
 	return stats
 }
 
+// StatsWide calculates the WideSummaryStats object for a stream of integers.
+// Unlike Stats, the total is tracked without overflow, and so the average is
+// accurate however large the values.
+func StatsWide[N ef.Integer](srcSt ef.Stream[N]) ef.WideSummaryStats[N] {
+	return Collect(srcSt, collect.StatsWide[N]())
+}
+
+// StatsExt calculates the SummaryStatsExt object for a stream of numbers,
+// which adds the variance and standard deviation to the usual stats.
+func StatsExt[N ef.Number](srcSt ef.Stream[N]) ef.SummaryStatsExt[N] {
+	return Collect(srcSt, collect.StatsExt[N]())
+}
+
+// Median calculates the exact median of a stream of numbers, or returns an
+// empty optional if the stream is empty. Note the whole stream is held in
+// memory to do so.
+func Median[N ef.Number](srcSt ef.Stream[N]) ef.Opt[float64] {
+	return Collect(srcSt, collect.Median[N]())
+}
+
+// Percentile calculates the exact given percentile (between 0 and 100) of a
+// stream of numbers, or returns an empty optional if the stream is empty. Note
+// the whole stream is held in memory to do so.
+func Percentile[N ef.Number](srcSt ef.Stream[N], percentile float64) ef.Opt[float64] {
+	return Collect(srcSt, collect.Percentile[N](percentile))
+}
+
+// ApproxPercentile estimates the given percentile (between 0 and 100) of a
+// stream of numbers in constant memory, or returns an empty optional if the
+// stream is empty.
+func ApproxPercentile[N ef.Number](srcSt ef.Stream[N], percentile float64) ef.Opt[float64] {
+	return Collect(srcSt, collect.ApproxPercentile[N](percentile))
+}
+
 // JoinString combines a stream of strings to a single string, adding `sep`
 // between each string.
 func JoinString(srcSt ef.Stream[string], sep string) string {
-	var sb strings.Builder
-	first := true
-	srcSt.Each(func(v string) {
-		if !first {
-			sb.WriteString(sep)
-		} else {
-			first = false
-		}
-		sb.WriteString(v)
+	return Collect(srcSt, collect.Joining(sep))
+}
+
+// GroupBy gathers the values of the stream into a map of slices, grouped by
+// the key returned from `keyOp`. Values keep their stream order within each
+// group.
+func GroupBy[T any, K comparable](srcSt ef.Stream[T], keyOp func(T) K) map[K][]T {
+	return GroupByCollect(srcSt, keyOp, collect.ToSlice[T]())
+}
+
+// GroupByCollect groups the values of the stream by the key returned from
+// `keyOp`, and gathers the values of each group with the given collector.
+//
+// Example:
+//
+//	countsByLen := GroupByCollect(words, func(w string) int {
+//	  return len(w)
+//	}, collect.Counting[string]())
+func GroupByCollect[T any, K comparable, A, R any](
+	srcSt ef.Stream[T],
+	keyOp func(T) K,
+	c ef.Collector[T, A, R],
+) map[K]R {
+	return Collect(srcSt, collect.GroupingBy(keyOp, c))
+}
+
+// PartitionBy splits the values of the stream in two - those that pass the
+// given check, and those that don't.
+func PartitionBy[T any](srcSt ef.Stream[T], checkOp func(T) bool) (matched, unmatched []T) {
+	return Collect(srcSt, collect.Partitioning(checkOp, collect.ToSlice[T]())).Get()
+}
+
+// Collect gathers the values of the stream with the given collector, and
+// returns the result.
+//
+// Example:
+//
+//	avgLen := Collect(words, collect.Mapping(
+//	  func(w string) int { return len(w) },
+//	  collect.Averaging[int]()))
+func Collect[T, A, R any](srcSt ef.Stream[T], c ef.Collector[T, A, R]) R {
+	acc := c.Supply()
+	srcSt.Each(func(v T) {
+		acc = c.Accumulate(acc, v)
 	})
-	return sb.String()
+	return c.Finish(acc)
+}
+
+// Pull converts the stream into a pair of functions for pull-style iteration.
+// Each call to `next` returns the next value in the stream and true, or a zero
+// value and false once the stream is exhausted. `stop` ends iteration early,
+// and must be called once the caller is done with the stream - as with
+// `ef.Puller`, the fallback for a forgotten stop is only a last resort.
+//
+// Example:
+//
+//	next, stop := Pull(OfVals(1, 2, 3))
+//	defer stop()
+//	for v, ok := next(); ok; v, ok = next() {
+//	  fmt.Println("value is - ", v)
+//	}
+func Pull[T any](srcSt ef.Stream[T]) (next func() (T, bool), stop func()) {
+	p := srcSt.Pull()
+	next = func() (T, bool) {
+		v := p.Next()
+		return v.Or(*new(T)), v.HasVal()
+	}
+	return next, p.Stop
+}
+
+// ToChan runs the stream on a new goroutine, and sends each value to the
+// returned channel, which has a buffer of `bufSize`. The channel is closed once
+// the stream is finished.
+//
+// The returned stop function ends the stream early and closes the channel, as
+// does cancelling the context. A reader that stops reading before the channel
+// is closed must call stop - otherwise the goroutine will block on its next
+// send forever. Calling stop after the stream has finished is harmless.
+//
+// Example:
+//
+//	ch, stop := ToChan(ctx, st, 0)
+//	defer stop()
+//	for v := range ch {
+//	  ...
+//	}
+func ToChan[T any](ctx context.Context, srcSt ef.Stream[T], bufSize int) (<-chan T, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	ch := make(chan T, bufSize)
+	go func() {
+		defer cancel()
+		defer close(ch)
+		srcSt.ExitableEach(func(val T) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+			select {
+			case ch <- val:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch, cancel
 }
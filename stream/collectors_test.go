@@ -1,9 +1,15 @@
 package stream
 
 import (
+	"context"
+	"errors"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/collect"
+	"github.com/BennettJames/ef/set"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,6 +33,21 @@ func TestStreamToMap(t *testing.T) {
 	})
 }
 
+func TestStreamToSet(t *testing.T) {
+	assert.Equal(t, set.Of("a", "b"), ToSet(OfVals("a", "b", "a")))
+	assert.Equal(t, set.Of[string](), ToSet(Empty[string]()))
+}
+
+func TestStreamToOrderedMap(t *testing.T) {
+	m := ToOrderedMap(OfVals(
+		ef.PairOf("c", 1),
+		ef.PairOf("a", 2),
+		ef.PairOf("b", 3),
+	))
+	assert.Equal(t, ef.Slice("c", "a", "b"), m.Keys())
+	assert.Equal(t, ef.NewOptValue(2), m.Get("a"))
+}
+
 func TestStreamToMapMerge(t *testing.T) {
 	t.Run("Basic", func(t *testing.T) {
 		st := OfVals(
@@ -42,6 +63,23 @@ func TestStreamToMapMerge(t *testing.T) {
 			}))
 	})
 
+	t.Run("KeepsMerged", func(t *testing.T) {
+		st := OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 5),
+			ef.PairOf("a", 2),
+			ef.PairOf("a", 3),
+		)
+		assert.Equal(t,
+			map[string]int{
+				"a": 6,
+				"b": 5,
+			},
+			ToMapMerge(st, func(key string, v1, v2 int) int {
+				return v1 + v2
+			}))
+	})
+
 	t.Run("Collision", func(t *testing.T) {
 		assert.Panics(t, func() {
 			ToMap(OfVals(
@@ -52,6 +90,68 @@ func TestStreamToMapMerge(t *testing.T) {
 	})
 }
 
+func TestStreamToMapRes(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2))
+		assert.Equal(t,
+			ef.NewResValue(map[string]int{"a": 1, "b": 2}),
+			ToMapRes(st))
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("a", 2),
+			ef.PairOf("a", 3),
+		), func(ef.Pair[string, int]) { readCount++ })
+		res := ToMapRes(st)
+		assert.True(t, res.IsErr())
+		assert.Equal(t, 2, readCount)
+
+		var dupErr *ef.DuplicateKeyError[string, int]
+		assert.True(t, errors.As(res.Err(), &dupErr))
+		assert.Equal(t, &ef.DuplicateKeyError[string, int]{
+			Key:       "a",
+			Existing:  1,
+			Duplicate: 2,
+		}, dupErr)
+	})
+}
+
+func TestStreamToMapResAll(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2))
+		assert.Equal(t,
+			ef.NewResValue(map[string]int{"a": 1, "b": 2}),
+			ToMapResAll(st))
+	})
+
+	t.Run("Collisions", func(t *testing.T) {
+		st := OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 2),
+			ef.PairOf("a", 3),
+			ef.PairOf("b", 4),
+			ef.PairOf("a", 5),
+		)
+		res := ToMapResAll(st)
+		assert.True(t, res.IsErr())
+
+		joined, ok := res.Err().(interface{ Unwrap() []error })
+		assert.True(t, ok)
+		assert.Equal(t, ef.Slice[error](
+			&ef.DuplicateKeyError[string, int]{Key: "a", Existing: 1, Duplicate: 3},
+			&ef.DuplicateKeyError[string, int]{Key: "b", Existing: 2, Duplicate: 4},
+			&ef.DuplicateKeyError[string, int]{Key: "a", Existing: 1, Duplicate: 5},
+		), joined.Unwrap())
+
+		var dupErr *ef.DuplicateKeyError[string, int]
+		assert.True(t, errors.As(res.Err(), &dupErr))
+		assert.Equal(t, "a", dupErr.Key)
+	})
+}
+
 func TestStreamReduce(t *testing.T) {
 	t.Run("Sum", func(t *testing.T) {
 		st := OfVals(1, 2, 3)
@@ -129,6 +229,83 @@ func TestStreamFind(t *testing.T) {
 	})
 }
 
+func TestStreamFirst(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(OfVals(1, 2, 3), func(int) { readCount++ })
+		assert.Equal(t, ef.NewOptValue(1), First(st))
+		assert.Equal(t, 1, readCount)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, ef.Opt[int]{}, First(Empty[int]()))
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		assert.Equal(t, ef.NewOptValue("a"), First(Repeat("a")))
+	})
+}
+
+func TestStreamLast(t *testing.T) {
+	assert.Equal(t, ef.NewOptValue(3), Last(OfVals(1, 2, 3)))
+	assert.Equal(t, ef.Opt[int]{}, Last(Empty[int]()))
+}
+
+func TestStreamNth(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(OfVals(1, 2, 3, 4), func(int) { readCount++ })
+		assert.Equal(t, ef.NewOptValue(3), Nth(st, 2))
+		assert.Equal(t, 3, readCount)
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		assert.Equal(t, ef.Opt[int]{}, Nth(OfVals(1, 2, 3), 3))
+		assert.Equal(t, ef.Opt[int]{}, Nth(OfVals(1, 2, 3), -1))
+	})
+}
+
+func TestStreamMinBy(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfVals("ccc", "a", "bb", "d")
+		assert.Equal(t,
+			ef.NewOptValue("a"),
+			MinBy(st, func(s string) int { return len(s) }))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Opt[string]{},
+			MinBy(Empty[string](), func(s string) int { return len(s) }))
+	})
+}
+
+func TestStreamMaxBy(t *testing.T) {
+	st := OfVals("a", "ccc", "bb", "ddd")
+	assert.Equal(t,
+		ef.NewOptValue("ccc"),
+		MaxBy(st, func(s string) int { return len(s) }))
+}
+
+func TestStreamSingle(t *testing.T) {
+	t.Run("Single", func(t *testing.T) {
+		assert.Equal(t, ef.NewResValue(1), Single(OfVals(1)))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		res := Single(Empty[int]())
+		assert.Equal(t, &ef.NotSingleError{Empty: true}, res.Err())
+	})
+
+	t.Run("Multiple", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(Repeat(1), func(int) { readCount++ })
+		res := Single(st)
+		assert.Equal(t, &ef.NotSingleError{Empty: false}, res.Err())
+		assert.Equal(t, 2, readCount)
+	})
+}
+
 func TestStreamAnyMatch(t *testing.T) {
 
 	t.Run("Empty", func(t *testing.T) {
@@ -209,8 +386,8 @@ func TestStreamStats(t *testing.T) {
 				Average: 0,
 				Size:    0,
 				Total:   0,
-				Min:     ef.MaxNumber[int](),
-				Max:     ef.MinNumber[int](),
+				Min:     ef.Opt[int]{},
+				Max:     ef.Opt[int]{},
 			},
 			Stats(st))
 	})
@@ -222,8 +399,8 @@ func TestStreamStats(t *testing.T) {
 				Average: 3,
 				Size:    5,
 				Total:   15,
-				Min:     1,
-				Max:     5,
+				Min:     ef.NewOptValue(1),
+				Max:     ef.NewOptValue(5),
 			},
 			Stats(st))
 	})
@@ -235,9 +412,269 @@ func TestStreamStats(t *testing.T) {
 				Average: -0.375,
 				Size:    4,
 				Total:   -1.5,
-				Min:     -10,
-				Max:     5,
+				Min:     ef.NewOptValue(-10.0),
+				Max:     ef.NewOptValue(5.0),
 			},
 			Stats(st))
 	})
 }
+
+func TestStreamCollect(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfVals(1, 2, 3)
+		assert.Equal(t, ef.Slice(1, 2, 3), Collect(st, collect.ToSlice[int]()))
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		st := OfVals("a", "bb", "ccc", "dddd")
+		avgLen := Collect(st, collect.Mapping(
+			func(s string) int { return len(s) },
+			collect.Averaging[int]()))
+		assert.Equal(t, 2.5, avgLen)
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := OfVals(1, 2, 3)
+		c := collect.Counting[int]()
+		assert.Equal(t, 3, Collect(st, c))
+		assert.Equal(t, 3, Collect(st, c))
+	})
+}
+
+func TestStreamGroupBy(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfVals("a", "cc", "b", "ddd")
+		assert.Equal(t,
+			map[int][]string{
+				1: ef.Slice("a", "b"),
+				2: ef.Slice("cc"),
+				3: ef.Slice("ddd"),
+			},
+			GroupBy(st, func(s string) int { return len(s) }))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t,
+			map[int][]string{},
+			GroupBy(Empty[string](), func(s string) int { return len(s) }))
+	})
+}
+
+func TestStreamGroupByCollect(t *testing.T) {
+	st := OfVals("a", "cc", "b", "ddd")
+	assert.Equal(t,
+		map[int]string{
+			1: "a+b",
+			2: "cc",
+			3: "ddd",
+		},
+		GroupByCollect(st, func(s string) int { return len(s) }, collect.Joining("+")))
+}
+
+func TestStreamPartitionBy(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		matched, unmatched := PartitionBy(OfVals(1, 2, 3, 4, 5), ef.Greater(2))
+		assert.Equal(t, ef.Slice(3, 4, 5), matched)
+		assert.Equal(t, ef.Slice(1, 2), unmatched)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		matched, unmatched := PartitionBy(Empty[int](), ef.Greater(2))
+		assert.Equal(t, ef.Slice[int](), matched)
+		assert.Equal(t, ef.Slice[int](), unmatched)
+	})
+}
+
+func TestStreamStatsChecked(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.NewResValue(ef.SummaryStats[int32]{
+				Average: 2,
+				Size:    3,
+				Total:   6,
+				Min:     ef.NewOptValue[int32](1),
+				Max:     ef.NewOptValue[int32](3),
+			}),
+			StatsChecked(OfVals[int32](1, 2, 3)))
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(Repeat[int8](50), func(int8) { readCount++ })
+		stats := StatsChecked(st)
+		var overflowErr *ef.OverflowError[int8]
+		assert.ErrorAs(t, stats.Err(), &overflowErr)
+		assert.Equal(t, 3, readCount)
+	})
+}
+
+func TestStreamStatsWide(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		stats := StatsWide(Limit(Repeat[int8](120), 10))
+		assert.Equal(t, 10, stats.Size)
+		assert.Equal(t, int64(1200), stats.Total.Int64())
+		assert.Equal(t, 120.0, stats.Average)
+		assert.Equal(t, ef.NewOptValue[int8](120), stats.Min)
+		assert.Equal(t, ef.NewOptValue[int8](120), stats.Max)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		stats := StatsWide(Empty[int32]())
+		assert.Equal(t, 0, stats.Size)
+		assert.Equal(t, int64(0), stats.Total.Int64())
+		assert.Equal(t, 0.0, stats.Average)
+		assert.True(t, stats.Min.IsEmpty())
+		assert.True(t, stats.Max.IsEmpty())
+	})
+}
+
+func TestStreamStatsExt(t *testing.T) {
+	stats := StatsExt(OfVals(1.0, 2.0, 3.0, 4.0))
+	assert.Equal(t, 4, stats.Size)
+	assert.Equal(t, 10.0, stats.Total)
+	assert.Equal(t, 2.5, stats.Average)
+	assert.Equal(t, 1.25, stats.Variance)
+	assert.InDelta(t, 5.0/3, stats.SampleVariance, 1e-12)
+}
+
+func TestStreamMedian(t *testing.T) {
+	assert.Equal(t, ef.NewOptValue(2.0), Median(OfVals(3, 1, 2)))
+	assert.Equal(t, ef.Opt[float64]{}, Median(Empty[int]()))
+}
+
+func TestStreamPercentile(t *testing.T) {
+	assert.Equal(t, ef.NewOptValue(95.0), Percentile(ef.RangeIncl(0, 100), 95))
+}
+
+func TestStreamApproxPercentile(t *testing.T) {
+	approx := ApproxPercentile(ef.Range(0, 100_000), 25)
+	assert.InDelta(t, 25_000, approx.UnsafeGet(), 250)
+}
+
+func TestStreamPull(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		next, stop := Pull(OfVals(1, 2))
+		defer stop()
+
+		v, ok := next()
+		assert.Equal(t, 1, v)
+		assert.True(t, ok)
+		v, ok = next()
+		assert.Equal(t, 2, v)
+		assert.True(t, ok)
+		v, ok = next()
+		assert.Equal(t, 0, v)
+		assert.False(t, ok)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		next, stop := Pull(OfVals(1, 2))
+		_, _ = next()
+		stop()
+		_, ok := next()
+		assert.False(t, ok)
+	})
+
+	t.Run("Abandoned", func(t *testing.T) {
+		finished := make(chan struct{})
+		func() {
+			next, _ := Pull(OfFn(func(opFn func(int) bool) {
+				defer close(finished)
+				for i := 0; opFn(i); i++ {
+				}
+			}))
+			_, _ = next()
+		}()
+
+		deadline := time.After(5 * time.Second)
+		for {
+			runtime.GC()
+			select {
+			case <-finished:
+				return
+			case <-deadline:
+				t.Fatal("abandoned pull was never cleaned up")
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
+
+func TestStreamToChan(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		ch, stop := ToChan(context.Background(), OfVals(1, 2, 3), 0)
+		defer stop()
+		readVals := ef.Slice[int]()
+		for v := range ch {
+			readVals = append(readVals, v)
+		}
+		assert.Equal(t, ef.Slice(1, 2, 3), readVals)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		readCount := 0
+		st := StreamPeek(Repeat(1), func(int) { readCount++ })
+		ch, _ := ToChan(ctx, st, 0)
+		assert.Equal(t, 1, <-ch)
+		cancel()
+
+		select {
+		case <-drain(ch):
+		case <-time.After(5 * time.Second):
+			t.Fatal("channel was not closed after cancel")
+		}
+		assert.Less(t, readCount, 4)
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		ch, stop := ToChan(context.Background(), Repeat(1), 0)
+		assert.Equal(t, 1, <-ch)
+		stop()
+		stop()
+
+		select {
+		case <-drain(ch):
+		case <-time.After(5 * time.Second):
+			t.Fatal("channel was not closed after stop")
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		ctx := context.Background()
+		ch, stop := ToChan(ctx, Iterate(0, func(v int) int { return v + 1 }), 4)
+		defer stop()
+		st := OfChan(ctx, ch)
+		assert.Equal(t, ef.Slice(0, 1, 2), Limit(st, 3).ToSlice())
+	})
+
+	t.Run("NoLeak", func(t *testing.T) {
+		ctx := context.Background()
+		before := runtime.NumGoroutine()
+		for i := 0; i < 100; i++ {
+			ch, stop := ToChan(ctx, OfVals(1, 2, 3, 4, 5), 0)
+			assert.Equal(t, ef.NewOptValue(1), First(OfChan(ctx, ch)))
+			stop()
+		}
+
+		deadline := time.After(5 * time.Second)
+		for runtime.NumGoroutine() > before {
+			select {
+			case <-deadline:
+				t.Fatalf("%d goroutines still running, up from %d",
+					runtime.NumGoroutine(), before)
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
+
+func drain[T any](ch <-chan T) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range ch {
+		}
+	}()
+	return done
+}
@@ -1,6 +1,16 @@
 package stream
 
-import "github.com/BennettJames/ef"
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/set"
+)
 
 // Of creates a stream out of several types that can be converted to a stream.
 func Of[T any, S ef.Streamable[T]](s S) ef.Stream[T] {
@@ -47,6 +57,22 @@ func OfFn[T any](iterFn func(func(T) bool)) ef.Stream[T] {
 	})
 }
 
+// OfSeq creates a stream out of a standard library iterator. Exiting early from
+// the stream (e.g. with `ExitableEach` or `Find`) stops the iterator as a
+// `break` in a range loop would.
+func OfSeq[T any](seq iter.Seq[T]) ef.Stream[T] {
+	return OfFn(seq)
+}
+
+// OfSeq2 creates a pair-stream out of a standard library key/value iterator.
+func OfSeq2[K, V any](seq iter.Seq2[K, V]) ef.Stream[ef.Pair[K, V]] {
+	return OfFn(func(opFn func(ef.Pair[K, V]) bool) {
+		seq(func(k K, v V) bool {
+			return opFn(ef.PairOf(k, v))
+		})
+	})
+}
+
 // Empty returns an empty stream.
 func Empty[T any]() ef.Stream[T] {
 	return OfSlice([]T{})
@@ -60,9 +86,226 @@ func OfMap[T comparable, U any](m map[T]U) ef.Stream[ef.Pair[T, U]] {
 	})
 }
 
+// OfMapSorted creates a stream out of a map like OfMap, but with the entries in
+// ascending order of their keys rather than a random one.
+func OfMapSorted[K cmp.Ordered, V any](m map[K]V) ef.Stream[ef.Pair[K, V]] {
+	return OfFn(func(opFn func(ef.Pair[K, V]) bool) {
+		keys := make([]K, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			if !opFn(ef.PairOf(k, m[k])) {
+				return
+			}
+		}
+	})
+}
+
+// OfSet creates a stream of the values in a set, in no particular order.
+//
+// Note sets can't be passed to `Of` - `ef.Streamable` allows any element type,
+// while sets only allow comparable ones.
+func OfSet[T comparable](s set.Set[T]) ef.Stream[T] {
+	return s.Stream()
+}
+
 // Concat combines any number of streams into a single stream.
 func Concat[T any](srcStreams ...ef.Stream[T]) ef.Stream[T] {
 	return ef.NewStream[T](&ef.MultiStream[T]{
 		Streams: srcStreams,
 	})
 }
+
+// Zip combines two streams into a stream of pairs, where the nth pair holds the
+// nth element of each stream. The stream ends when either source stream does.
+func Zip[A, B any](
+	srcA ef.Stream[A],
+	srcB ef.Stream[B],
+) ef.Stream[ef.Pair[A, B]] {
+	return ZipWith(srcA, srcB, ef.PairOf[A, B])
+}
+
+// ZipWith combines two streams element-by-element with the given function. The
+// stream ends when either source stream does.
+//
+// Note that if the second stream is shorter, one extra element is read from the
+// first before the end is detected.
+func ZipWith[A, B, C any](
+	srcA ef.Stream[A],
+	srcB ef.Stream[B],
+	zipOp func(A, B) C,
+) ef.Stream[C] {
+	return OfFn(func(opFn func(C) bool) {
+		pullB := srcB.Pull()
+		defer pullB.Stop()
+		srcA.ExitableEach(func(a A) bool {
+			b := pullB.Next()
+			if b.IsEmpty() {
+				return false
+			}
+			return opFn(zipOp(a, b.UnsafeGet()))
+		})
+	})
+}
+
+// Zip3 combines three streams into a stream of triples, where the nth triple
+// holds the nth element of each stream. The stream ends when any source stream
+// does.
+func Zip3[A, B, C any](
+	srcA ef.Stream[A],
+	srcB ef.Stream[B],
+	srcC ef.Stream[C],
+) ef.Stream[ef.Triple[A, B, C]] {
+	return OfFn(func(opFn func(ef.Triple[A, B, C]) bool) {
+		pullB, pullC := srcB.Pull(), srcC.Pull()
+		defer pullB.Stop()
+		defer pullC.Stop()
+		srcA.ExitableEach(func(a A) bool {
+			b := pullB.Next()
+			if b.IsEmpty() {
+				return false
+			}
+			c := pullC.Next()
+			if c.IsEmpty() {
+				return false
+			}
+			return opFn(ef.TripleOf(a, b.UnsafeGet(), c.UnsafeGet()))
+		})
+	})
+}
+
+// ZipLongest combines two streams into a stream of pairs like Zip, but
+// continues until both streams are exhausted. Once one of the streams ends, its
+// side of each following pair is an empty optional.
+func ZipLongest[A, B any](
+	srcA ef.Stream[A],
+	srcB ef.Stream[B],
+) ef.Stream[ef.Pair[ef.Opt[A], ef.Opt[B]]] {
+	return OfFn(func(opFn func(ef.Pair[ef.Opt[A], ef.Opt[B]]) bool) {
+		pullB := srcB.Pull()
+		defer pullB.Stop()
+		advance := true
+		srcA.ExitableEach(func(a A) bool {
+			advance = opFn(ef.PairOf(ef.NewOptValue(a), pullB.Next()))
+			return advance
+		})
+		if !advance {
+			return
+		}
+		for b := pullB.Next(); b.HasVal(); b = pullB.Next() {
+			if !opFn(ef.PairOf(ef.Opt[A]{}, b)) {
+				return
+			}
+		}
+	})
+}
+
+// Iterate returns an infinite stream that starts with `seed`, and where each
+// following value is the result of calling `iterOp` on the previous one.
+//
+// Example:
+//
+//	backoffs := Limit(Iterate(time.Second, func(d time.Duration) time.Duration {
+//	  return d * 2
+//	}), 5)
+func Iterate[T any](seed T, iterOp func(T) T) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		for v := seed; opFn(v); v = iterOp(v) {
+		}
+	})
+}
+
+// Generate returns an infinite stream where each value is the result of a call
+// to `genOp`.
+func Generate[T any](genOp func() T) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		for opFn(genOp()) {
+		}
+	})
+}
+
+// Repeat returns an infinite stream that consists of the given value over and
+// over.
+func Repeat[T any](val T) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		for opFn(val) {
+		}
+	})
+}
+
+// Cycle returns an infinite stream that iterates the source stream, and starts
+// again from its beginning each time it is exhausted. If the source stream is
+// empty, then so is the returned stream.
+func Cycle[T any](srcSt ef.Stream[T]) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		for {
+			advance, yielded := true, false
+			srcSt.ExitableEach(func(val T) bool {
+				yielded = true
+				advance = opFn(val)
+				return advance
+			})
+			if !advance || !yielded {
+				return
+			}
+		}
+	})
+}
+
+// OfLines returns a stream of the lines read from the given reader, with line
+// endings stripped. See `OfScanner` for how errors are reported.
+func OfLines(r io.Reader) ef.Stream[ef.Res[string]] {
+	return OfScanner(bufio.NewScanner(r))
+}
+
+// OfSplit returns a stream of the tokens read from the given reader, as split
+// by `splitFn` (e.g. `bufio.ScanWords`). See `OfScanner` for how errors are
+// reported.
+func OfSplit(r io.Reader, splitFn bufio.SplitFunc) ef.Stream[ef.Res[string]] {
+	sc := bufio.NewScanner(r)
+	sc.Split(splitFn)
+	return OfScanner(sc)
+}
+
+// OfScanner returns a stream of the tokens read by the given scanner. Each
+// token is a value result; if the scanner stops due to an error, then it is
+// the final element of the stream as an error result.
+//
+// Reading stops as soon as the stream is exited early, so no more of the
+// underlying reader is consumed than necessary. Note that a scanner can only
+// be read once, so neither can the stream.
+func OfScanner(sc *bufio.Scanner) ef.Stream[ef.Res[string]] {
+	return OfFn(func(opFn func(ef.Res[string]) bool) {
+		for sc.Scan() {
+			if !opFn(ef.NewResValue(sc.Text())) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			opFn(ef.NewResError[string](err))
+		}
+	})
+}
+
+// OfChan returns a stream of the values received from the given channel. The
+// stream ends when the channel is closed or the context is cancelled.
+//
+// Exiting the stream early simply stops receiving from the channel - it does
+// not close it, nor drain any values that remain. If the channel came from
+// ToChan, call its stop function so the sending goroutine exits.
+func OfChan[T any](ctx context.Context, ch <-chan T) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		for ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-ch:
+				if !ok || !opFn(v) {
+					return
+				}
+			}
+		}
+	})
+}
@@ -1,9 +1,17 @@
 package stream
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/set"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +40,43 @@ func TestStreamOfIndexedSlice(t *testing.T) {
 	), asList)
 }
 
+func TestStreamOfSeq(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfSeq(slices.Values(ef.Slice(1, 2, 3)))
+		assert.Equal(t, ef.Slice(1, 2, 3), st.ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		yieldCount := 0
+		st := OfSeq(func(yield func(int) bool) {
+			for i := 0; i < 10; i++ {
+				yieldCount++
+				if !yield(i) {
+					return
+				}
+			}
+		})
+		assert.Equal(t, ef.NewOptValue(2), Find(st, ef.Equal(2)))
+		assert.Equal(t, 3, yieldCount)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		st := OfSeq(OfVals("a", "b", "c").Seq())
+		assert.Equal(t, ef.Slice("a", "b", "c"), slices.Collect(st.Seq()))
+	})
+}
+
+func TestStreamOfSeq2(t *testing.T) {
+	st := OfSeq2(slices.All(ef.Slice("a", "b")))
+	assert.Equal(t, ef.Slice(
+		ef.PairOf(0, "a"),
+		ef.PairOf(1, "b"),
+	), st.ToSlice())
+
+	m := map[string]int{"a": 1, "b": 2}
+	assert.Equal(t, m, ToMap(OfSeq2(maps.All(m))))
+}
+
 func TestStreamEmpty(t *testing.T) {
 	st := Empty[string]()
 	assert.Equal(t, ef.Slice[string](), st.ToSlice())
@@ -48,6 +93,29 @@ func TestStreamOfMap(t *testing.T) {
 	), st.ToSlice())
 }
 
+func TestStreamOfMapSorted(t *testing.T) {
+	m := map[string]int{"c": 1, "a": 2, "d": 3, "b": 4}
+	assert.Equal(t,
+		ef.Slice(
+			ef.PairOf("a", 2),
+			ef.PairOf("b", 4),
+			ef.PairOf("c", 1),
+			ef.PairOf("d", 3),
+		),
+		OfMapSorted(m).ToSlice())
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 2)),
+		Limit(OfMapSorted(m), 1).ToSlice())
+}
+
+func TestStreamOfSet(t *testing.T) {
+	s := set.Of(1, 2, 3)
+	assert.Equal(t, ef.Slice(1, 2, 3), Sorted(OfSet(s)).ToSlice())
+	assert.Equal(t,
+		ef.Slice(2, 3),
+		Sorted(StreamKeep(OfSet(s), ef.Not(set.Of(1).Contains))).ToSlice())
+}
+
 func TestStreamConcat(t *testing.T) {
 
 	var _ = StreamMap(Of[int](ef.Slice(1, 2, 3)), func(v int) string {
@@ -67,6 +135,17 @@ func TestStreamConcat(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		assert.Equal(t, ef.Slice[int](), Concat[int]().ToSlice())
 	})
+
+	t.Run("Break", func(t *testing.T) {
+		readVals := ef.Slice[int]()
+		for v := range Concat(OfVals(1, 2), OfVals(3, 4)).Seq() {
+			readVals = append(readVals, v)
+			if v == 2 {
+				break
+			}
+		}
+		assert.Equal(t, ef.Slice(1, 2), readVals)
+	})
 }
 
 func TestStreamOf(t *testing.T) {
@@ -107,3 +186,234 @@ func TestStreamOf(t *testing.T) {
 	})
 
 }
+
+func TestStreamZip(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.PairOf(1, "a"), ef.PairOf(2, "b")),
+			Zip(OfVals(1, 2), OfVals("a", "b")).ToSlice())
+	})
+
+	t.Run("Uneven", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.PairOf(1, "a")),
+			Zip(OfVals(1, 2, 3), OfVals("a")).ToSlice())
+		assert.Equal(t,
+			ef.Slice(ef.PairOf(1, "a")),
+			Zip(OfVals(1), OfVals("a", "b", "c")).ToSlice())
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		ids := Iterate(0, func(v int) int { return v + 1 })
+		assert.Equal(t,
+			ef.Slice(ef.PairOf(0, "a"), ef.PairOf(1, "b")),
+			Zip(ids, OfVals("a", "b")).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		st := Zip(Repeat(1), Repeat("a"))
+		assert.Equal(t,
+			ef.Slice(ef.PairOf(1, "a"), ef.PairOf(1, "a")),
+			Limit(st, 2).ToSlice())
+	})
+}
+
+func TestStreamZipWith(t *testing.T) {
+	st := ZipWith(OfVals(1, 2, 3), OfVals(10, 20, 30), ef.Add[int])
+	assert.Equal(t, ef.Slice(11, 22, 33), st.ToSlice())
+}
+
+func TestStreamZip3(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.TripleOf(1, "a", true), ef.TripleOf(2, "b", false)),
+			Zip3(OfVals(1, 2), OfVals("a", "b"), OfVals(true, false)).ToSlice())
+	})
+
+	t.Run("Uneven", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.TripleOf(1, "a", true)),
+			Zip3(OfVals(1, 2, 3), OfVals("a", "b"), OfVals(true)).ToSlice())
+		assert.Equal(t,
+			ef.Slice(ef.TripleOf(1, "a", true)),
+			Zip3(OfVals(1), OfVals("a", "b"), OfVals(true, false)).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		st := Zip3(Repeat(1), Repeat("a"), Repeat(true))
+		assert.Equal(t,
+			ef.Slice(ef.TripleOf(1, "a", true), ef.TripleOf(1, "a", true)),
+			Limit(st, 2).ToSlice())
+	})
+}
+
+func TestStreamZipLongest(t *testing.T) {
+	t.Run("LongerFirst", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(
+				ef.PairOf(ef.NewOptValue(1), ef.NewOptValue("a")),
+				ef.PairOf(ef.NewOptValue(2), ef.Opt[string]{}),
+			),
+			ZipLongest(OfVals(1, 2), OfVals("a")).ToSlice())
+	})
+
+	t.Run("LongerSecond", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(
+				ef.PairOf(ef.NewOptValue(1), ef.NewOptValue("a")),
+				ef.PairOf(ef.Opt[int]{}, ef.NewOptValue("b")),
+			),
+			ZipLongest(OfVals(1), OfVals("a", "b")).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		st := ZipLongest(OfVals(1), Repeat("a"))
+		assert.Equal(t,
+			ef.Slice(
+				ef.PairOf(ef.NewOptValue(1), ef.NewOptValue("a")),
+				ef.PairOf(ef.Opt[int]{}, ef.NewOptValue("a")),
+			),
+			Limit(st, 2).ToSlice())
+	})
+}
+
+func TestStreamIterate(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := Iterate(1, func(v int) int { return v * 2 })
+		assert.Equal(t, ef.Slice(1, 2, 4, 8), Limit(st, 4).ToSlice())
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		st := Iterate(1, func(v int) int { return v * 3 })
+		assert.Equal(t, ef.NewOptValue(81), Find(st, ef.Greater(50)))
+	})
+}
+
+func TestStreamGenerate(t *testing.T) {
+	next := 0
+	st := Generate(func() int {
+		next++
+		return next
+	})
+	assert.Equal(t, ef.Slice(1, 2, 3), Limit(st, 3).ToSlice())
+	assert.Equal(t, 3, next)
+	assert.True(t, Match(st, ef.Equal(5)))
+	assert.Equal(t, 5, next)
+}
+
+func TestStreamRepeat(t *testing.T) {
+	assert.Equal(t, ef.Slice("a", "a", "a"), Limit(Repeat("a"), 3).ToSlice())
+	assert.False(t, AllMatch(Repeat(1), ef.Equal(2)))
+}
+
+func TestStreamCycle(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := Cycle(OfVals(1, 2, 3))
+		assert.Equal(t, ef.Slice(1, 2, 3, 1, 2), Limit(st, 5).ToSlice())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, ef.Slice[int](), Cycle(Empty[int]()).ToSlice())
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		st := Cycle(OfVals(1, 2, 3))
+		assert.Equal(t, ef.NewOptValue(3), Find(st, ef.Equal(3)))
+	})
+}
+
+func TestStreamOfLines(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := OfLines(strings.NewReader("a\nb\r\nc"))
+		assert.Equal(t, ef.Slice(
+			ef.NewResValue("a"),
+			ef.NewResValue("b"),
+			ef.NewResValue("c"),
+		), st.ToSlice())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		st := OfLines(strings.NewReader(""))
+		assert.Equal(t, ef.Slice[ef.Res[string]](), st.ToSlice())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		r := io.MultiReader(strings.NewReader("a\nb\n"), &errReader{err: readErr})
+		st := OfLines(r)
+		assert.Equal(t, ef.Slice(
+			ef.NewResValue("a"),
+			ef.NewResValue("b"),
+			ef.NewResError[string](readErr),
+		), st.ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		r := &countingReader{r: strings.NewReader("a\nb\nc\n")}
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 2), 2)
+		Limit(OfScanner(sc), 1).ToSlice()
+		assert.Equal(t, 2, r.read)
+	})
+}
+
+func TestStreamOfSplit(t *testing.T) {
+	st := OfSplit(strings.NewReader("a b  c\nd"), bufio.ScanWords)
+	assert.Equal(t, ef.Slice(
+		ef.NewResValue("a"),
+		ef.NewResValue("b"),
+		ef.NewResValue("c"),
+		ef.NewResValue("d"),
+	), st.ToSlice())
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += n
+	return n, err
+}
+
+func TestStreamOfChan(t *testing.T) {
+	t.Run("Closed", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+		assert.Equal(t, ef.Slice(1, 2, 3), OfChan(context.Background(), ch).ToSlice())
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		go func() {
+			ch <- 1
+			ch <- 2
+			cancel()
+		}()
+		assert.Equal(t, ef.Slice(1, 2), OfChan(ctx, ch).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		st := OfChan(context.Background(), ch)
+		assert.Equal(t, ef.NewOptValue(2), Find(st, ef.Equal(2)))
+		assert.Equal(t, 3, <-ch)
+	})
+}
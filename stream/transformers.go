@@ -1,6 +1,17 @@
 package stream
 
-import "github.com/BennettJames/ef"
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/heap"
+)
+
+// maxPrealloc caps how much capacity is reserved up front for slices sized by a
+// caller-provided count, since the stream may hold far fewer elements.
+const maxPrealloc = 64
 
 // StreamMap transforms each value in the input stream into a new value with the
 // provided function, and returns a new stream with the result.
@@ -10,6 +21,45 @@ func StreamMap[T, U any](srcSt ef.Stream[T], mapOp func(v T) U) ef.Stream[U] {
 	})
 }
 
+// FlatMap transforms each value in the input stream into any number of values
+// with the provided function, and returns a stream of all of them in order.
+// The function can return anything streamable - e.g. a slice, optional or
+// another stream.
+//
+// Note the output type generally can't be inferred, so must be specified.
+// Example:
+//
+//	words := FlatMap[string](lines, func(line string) []string {
+//	  return strings.Fields(line)
+//	})
+func FlatMap[U, T any, S ef.Streamable[U]](
+	srcSt ef.Stream[T],
+	mapOp func(v T) S,
+) ef.Stream[U] {
+	return ef.StreamTransform(srcSt, func(val T, nextOp func(U) bool) bool {
+		advance := true
+		Of[U](mapOp(val)).ExitableEach(func(inner U) bool {
+			advance = nextOp(inner)
+			return advance
+		})
+		return advance
+	})
+}
+
+// Flatten combines a stream of streamable values (e.g. a stream of streams, or
+// of optionals) into a single stream of all their values.
+func Flatten[T any, S ef.Streamable[T]](srcSt ef.Stream[S]) ef.Stream[T] {
+	return FlatMap[T](srcSt, func(v S) S {
+		return v
+	})
+}
+
+// FlattenSlices combines a stream of slices into a single stream of all their
+// values.
+func FlattenSlices[T any](srcSt ef.Stream[[]T]) ef.Stream[T] {
+	return Flatten[T](srcSt)
+}
+
 // StreamPeek will call the function on each element in the stream, but without
 // any other side effects on the stream.
 func StreamPeek[T any](srcSt ef.Stream[T], peekOp func(v T)) ef.Stream[T] {
@@ -43,6 +93,334 @@ func StreamRemove[T any](srcSt ef.Stream[T], removeOp func(T) bool) ef.Stream[T]
 	})
 }
 
+// Limit returns a stream consisting of at most the first `n` elements of the
+// source stream. Once `n` elements have been read, no more are pulled from the
+// source - so this is safe to use on infinite streams.
+func Limit[T any](srcSt ef.Stream[T], n int) ef.Stream[T] {
+	if n <= 0 {
+		return Empty[T]()
+	}
+	return ef.StreamTransformInit(srcSt, func() func(T, func(T) bool) bool {
+		count := 0
+		return func(val T, nextOp func(T) bool) bool {
+			count++
+			return nextOp(val) && count < n
+		}
+	})
+}
+
+// Skip returns a stream consisting of the elements of the source stream after
+// the first `n` elements have been discarded.
+func Skip[T any](srcSt ef.Stream[T], n int) ef.Stream[T] {
+	return ef.StreamTransformInit(srcSt, func() func(T, func(T) bool) bool {
+		skipped := 0
+		return func(val T, nextOp func(T) bool) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return nextOp(val)
+		}
+	})
+}
+
+// TakeWhile returns a stream consisting of the elements of the source stream
+// up until the first one that does not pass the given check. Iteration of the
+// source stops at that element.
+func TakeWhile[T any](srcSt ef.Stream[T], takeOp func(T) bool) ef.Stream[T] {
+	return ef.StreamTransform(srcSt, func(val T, nextOp func(T) bool) bool {
+		if !takeOp(val) {
+			return false
+		}
+		return nextOp(val)
+	})
+}
+
+// DropWhile returns a stream that discards elements of the source stream until
+// one fails the given check, then consists of that element and all the
+// elements that follow it.
+func DropWhile[T any](srcSt ef.Stream[T], dropOp func(T) bool) ef.Stream[T] {
+	return ef.StreamTransformInit(srcSt, func() func(T, func(T) bool) bool {
+		dropping := true
+		return func(val T, nextOp func(T) bool) bool {
+			if dropping && dropOp(val) {
+				return true
+			}
+			dropping = false
+			return nextOp(val)
+		}
+	})
+}
+
+// Scan is a running version of `ReduceInit` - it combines each element of the
+// source stream into an accumulated value, starting with `initVal`, and emits
+// the accumulated value after each element.
+//
+// Example:
+//
+//	Scan(OfVals(1, 2, 3), 0, ef.Add[int]) // == [1, 3, 6]
+func Scan[T, U any](
+	srcSt ef.Stream[T],
+	initVal U,
+	scanOp func(total U, val T) U,
+) ef.Stream[U] {
+	return ef.StreamTransformInit(srcSt, func() func(T, func(U) bool) bool {
+		total := initVal
+		return func(val T, nextOp func(U) bool) bool {
+			total = scanOp(total, val)
+			return nextOp(total)
+		}
+	})
+}
+
+// RunningSum returns a stream of the sum of all the source stream's elements
+// up to and including each one.
+func RunningSum[N ef.Number](srcSt ef.Stream[N]) ef.Stream[N] {
+	return Scan(srcSt, 0, ef.Add[N])
+}
+
+// RunningMin returns a stream of the lowest of the source stream's elements up
+// to and including each one.
+func RunningMin[N ef.Number](srcSt ef.Stream[N]) ef.Stream[N] {
+	return runningBest(srcSt, ef.Min[N])
+}
+
+// RunningMax returns a stream of the highest of the source stream's elements
+// up to and including each one.
+func RunningMax[N ef.Number](srcSt ef.Stream[N]) ef.Stream[N] {
+	return runningBest(srcSt, ef.Max[N])
+}
+
+// runningBest emits the first element as-is, and then the result of `pickOp`
+// on the previous output and each following element. Unlike Scan, this needs no
+// starting value, so it works for named numeric types that have no known bound.
+func runningBest[N ef.Number](srcSt ef.Stream[N], pickOp func(a, b N) N) ef.Stream[N] {
+	return ef.StreamTransformInit(srcSt, func() func(N, func(N) bool) bool {
+		var best ef.Opt[N]
+		return func(val N, nextOp func(N) bool) bool {
+			if best.HasVal() {
+				val = pickOp(best.UnsafeGet(), val)
+			}
+			best = ef.NewOptValue(val)
+			return nextOp(val)
+		}
+	})
+}
+
+// Chunk returns a stream that groups the elements of the source stream into
+// slices of length `n`. If the source stream doesn't divide evenly, the final
+// chunk holds whatever elements are left. Panics if `n` is not positive.
+//
+// Example:
+//
+//	Chunk(OfVals(1, 2, 3, 4, 5), 2) // == [[1, 2], [3, 4], [5]]
+func Chunk[T any](srcSt ef.Stream[T], n int) ef.Stream[[]T] {
+	ef.AssertMsgf(n > 0, "Chunk: size must be positive, got %d", n)
+	return ef.StreamTransformFlush(srcSt, func() (
+		func(T, func([]T) bool) bool,
+		func(func([]T) bool),
+	) {
+		chunk := make([]T, 0, min(n, maxPrealloc))
+		op := func(val T, nextOp func([]T) bool) bool {
+			chunk = append(chunk, val)
+			if len(chunk) < n {
+				return true
+			}
+			full := chunk
+			chunk = make([]T, 0, min(n, maxPrealloc))
+			return nextOp(full)
+		}
+		flush := func(nextOp func([]T) bool) {
+			if len(chunk) > 0 {
+				nextOp(chunk)
+			}
+		}
+		return op, flush
+	})
+}
+
+// Window returns a stream of sliding windows over the source stream - each is
+// a slice of `size` consecutive elements, and each window starts `step`
+// elements after the previous one. Only full windows are emitted, so a source
+// stream with fewer than `size` elements produces none. Panics if either size
+// or step is not positive.
+//
+// Example:
+//
+//	Window(OfVals(1, 2, 3, 4, 5), 3, 1) // == [[1, 2, 3], [2, 3, 4], [3, 4, 5]]
+//	Window(OfVals(1, 2, 3, 4, 5), 2, 3) // == [[1, 2], [4, 5]]
+func Window[T any](srcSt ef.Stream[T], size, step int) ef.Stream[[]T] {
+	ef.AssertMsgf(size > 0, "Window: size must be positive, got %d", size)
+	ef.AssertMsgf(step > 0, "Window: step must be positive, got %d", step)
+	return ef.StreamTransformInit(srcSt, func() func(T, func([]T) bool) bool {
+		window := make([]T, 0, min(size, maxPrealloc))
+		skip := 0
+		return func(val T, nextOp func([]T) bool) bool {
+			if skip > 0 {
+				skip--
+				return true
+			}
+			window = append(window, val)
+			if len(window) < size {
+				return true
+			}
+			full := slices.Clone(window)
+			if step >= size {
+				window = window[:0]
+				skip = step - size
+			} else {
+				window = append(window[:0], window[step:]...)
+			}
+			return nextOp(full)
+		}
+	})
+}
+
+// ChunkBy returns a stream that groups adjacent elements of the source stream
+// that have the same key into slices.
+//
+// Example:
+//
+//	ChunkBy(OfVals(1, 3, 2, 4, 5), isOdd) // == [[1, 3], [2, 4], [5]]
+func ChunkBy[T any, K comparable](srcSt ef.Stream[T], keyOp func(T) K) ef.Stream[[]T] {
+	return ef.StreamTransformFlush(srcSt, func() (
+		func(T, func([]T) bool) bool,
+		func(func([]T) bool),
+	) {
+		var chunk []T
+		var chunkKey K
+		op := func(val T, nextOp func([]T) bool) bool {
+			key := keyOp(val)
+			if len(chunk) == 0 || key == chunkKey {
+				chunk = append(chunk, val)
+				chunkKey = key
+				return true
+			}
+			full := chunk
+			chunk, chunkKey = []T{val}, key
+			return nextOp(full)
+		}
+		flush := func(nextOp func([]T) bool) {
+			if len(chunk) > 0 {
+				nextOp(chunk)
+			}
+		}
+		return op, flush
+	})
+}
+
+// Distinct returns a stream of the elements of the source stream with any
+// repeats removed. Elements are kept in the order they are first seen.
+//
+// Note every distinct element is held in memory for the duration of an
+// iteration. For streams where repeats are always adjacent (e.g. sorted
+// streams), `DistinctAdjacent` avoids this.
+func Distinct[T comparable](srcSt ef.Stream[T]) ef.Stream[T] {
+	return DistinctBy(srcSt, func(v T) T {
+		return v
+	})
+}
+
+// DistinctBy returns a stream of the elements of the source stream, removing
+// any element whose key was already seen in an earlier element.
+func DistinctBy[T any, K comparable](srcSt ef.Stream[T], keyOp func(T) K) ef.Stream[T] {
+	return ef.StreamTransformInit(srcSt, func() func(T, func(T) bool) bool {
+		seen := make(map[K]struct{})
+		return func(val T, nextOp func(T) bool) bool {
+			key := keyOp(val)
+			if _, exists := seen[key]; exists {
+				return true
+			}
+			seen[key] = struct{}{}
+			return nextOp(val)
+		}
+	})
+}
+
+// DistinctAdjacent returns a stream of the elements of the source stream with
+// consecutive repeats removed.
+//
+// Example:
+//
+//	DistinctAdjacent(OfVals(1, 1, 2, 1)) // == [1, 2, 1]
+func DistinctAdjacent[T comparable](srcSt ef.Stream[T]) ef.Stream[T] {
+	return ef.StreamTransformInit(srcSt, func() func(T, func(T) bool) bool {
+		var last ef.Opt[T]
+		return func(val T, nextOp func(T) bool) bool {
+			if last.HasVal() && last.UnsafeGet() == val {
+				return true
+			}
+			last = ef.NewOptValue(val)
+			return nextOp(val)
+		}
+	})
+}
+
+// Sorted returns a stream of the elements of the source stream in ascending
+// order.
+//
+// Note that sorting requires the entire source stream to be buffered before
+// the first value is emitted, so this cannot be used on infinite streams.
+func Sorted[T cmp.Ordered](srcSt ef.Stream[T]) ef.Stream[T] {
+	return sortStream(srcSt, slices.Sort[[]T])
+}
+
+// SortBy returns a stream of the elements of the source stream, sorted with
+// the given less function. The sort is not guaranteed to be stable; use
+// `SortStable` if the order of equal elements matters.
+func SortBy[T any](srcSt ef.Stream[T], less func(a, b T) bool) ef.Stream[T] {
+	return sortStream(srcSt, func(vals []T) {
+		sort.Slice(vals, func(i, j int) bool {
+			return less(vals[i], vals[j])
+		})
+	})
+}
+
+// SortStable is as SortBy, but keeps equal elements in their original order.
+func SortStable[T any](srcSt ef.Stream[T], less func(a, b T) bool) ef.Stream[T] {
+	return sortStream(srcSt, func(vals []T) {
+		sort.SliceStable(vals, func(i, j int) bool {
+			return less(vals[i], vals[j])
+		})
+	})
+}
+
+// TopK returns a stream of the first `n` elements the source stream would have
+// if it were sorted with the given less function, in sorted order.
+//
+// Unlike sorting the whole stream, this only ever holds `n` elements in memory
+// at a time.
+func TopK[T any](srcSt ef.Stream[T], n int, less func(a, b T) bool) ef.Stream[T] {
+	if n <= 0 {
+		return Empty[T]()
+	}
+	return OfFn(func(opFn func(T) bool) {
+		// the heap is reversed, so its top is always the value that would be the
+		// first evicted.
+		h := heap.New(func(a, b T) bool { return less(b, a) })
+		srcSt.Each(func(val T) {
+			if h.Len() < n {
+				h.Push(val)
+			} else if less(val, h.Peek().UnsafeGet()) {
+				h.ReplaceTop(val)
+			}
+		})
+		vals := make([]T, h.Len())
+		for i := len(vals) - 1; i >= 0; i-- {
+			vals[i] = h.Pop().UnsafeGet()
+		}
+		OfSlice(vals).ExitableEach(opFn)
+	})
+}
+
+func sortStream[T any](srcSt ef.Stream[T], sortOp func([]T)) ef.Stream[T] {
+	return OfFn(func(opFn func(T) bool) {
+		vals := srcSt.ToSlice()
+		sortOp(vals)
+		OfSlice(vals).ExitableEach(opFn)
+	})
+}
+
 // Each will perform the given function on each element of the input.
 //
 // Note this takes any streamable value as input - e.g. a stream or list can
@@ -2,6 +2,7 @@ package stream
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/BennettJames/ef"
@@ -17,6 +18,64 @@ func TestStreamMap(t *testing.T) {
 		}).ToSlice())
 }
 
+func TestStreamFlatMap(t *testing.T) {
+	t.Run("Slice", func(t *testing.T) {
+		input := OfVals("a b", "", "c")
+		assert.Equal(t,
+			ef.Slice("a", "b", "c"),
+			FlatMap[string](input, strings.Fields).ToSlice())
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		input := OfVals(1, 2, 3)
+		assert.Equal(t,
+			ef.Slice(1, 2, 2, 3, 3, 3),
+			FlatMap[int](input, func(v int) ef.Stream[int] {
+				return Limit(Repeat(v), v)
+			}).ToSlice())
+	})
+
+	t.Run("Opt", func(t *testing.T) {
+		input := OfVals(1, 2, 3, 4)
+		assert.Equal(t,
+			ef.Slice(2, 4),
+			FlatMap[int](input, func(v int) ef.Opt[int] {
+				if v%2 == 0 {
+					return ef.NewOptValue(v)
+				}
+				return ef.Opt[int]{}
+			}).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		outerCount, innerCount := 0, 0
+		input := StreamPeek(OfVals(1, 2, 3), func(int) { outerCount++ })
+		st := FlatMap[int](input, func(v int) ef.Stream[int] {
+			return StreamPeek(OfVals(v, v*10, v*100), func(int) { innerCount++ })
+		})
+		assert.Equal(t, ef.Slice(1, 10, 100, 2), Limit(st, 4).ToSlice())
+		assert.Equal(t, 2, outerCount)
+		assert.Equal(t, 4, innerCount)
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		st := FlatMap[int](Iterate(0, func(v int) int { return v + 1 }), func(v int) []int {
+			return ef.Slice(v, -v)
+		})
+		assert.Equal(t, ef.Slice(0, 0, 1, -1, 2), Limit(st, 5).ToSlice())
+	})
+}
+
+func TestStreamFlatten(t *testing.T) {
+	input := OfVals(OfVals(1, 2), Empty[int](), OfVals(3))
+	assert.Equal(t, ef.Slice(1, 2, 3), Flatten[int](input).ToSlice())
+}
+
+func TestStreamFlattenSlices(t *testing.T) {
+	input := OfVals(ef.Slice(1, 2), ef.Slice[int](), ef.Slice(3))
+	assert.Equal(t, ef.Slice(1, 2, 3), FlattenSlices(input).ToSlice())
+}
+
 func TestStreamPeek(t *testing.T) {
 	count := 0
 	input := OfVals(1, 2, 3)
@@ -44,6 +103,382 @@ func TestStreamRemove(t *testing.T) {
 	assert.Equal(t, ef.Slice(1, 3), filtered)
 }
 
+func TestStreamLimit(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2, 3),
+			Limit(OfVals(1, 2, 3, 4, 5), 3).ToSlice())
+	})
+
+	t.Run("ShortSource", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2),
+			Limit(OfVals(1, 2), 3).ToSlice())
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(OfVals(1, 2, 3), func(int) { readCount++ })
+		assert.Equal(t, ef.Slice[int](), Limit(st, 0).ToSlice())
+		assert.Equal(t, 0, readCount)
+	})
+
+	t.Run("StopsPulling", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(ef.Range(0, 1000), func(int) { readCount++ })
+		assert.Equal(t, ef.Slice(0, 1, 2), Limit(st, 3).ToSlice())
+		assert.Equal(t, 3, readCount)
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := Limit(OfVals(1, 2, 3), 2)
+		assert.Equal(t, ef.Slice(1, 2), st.ToSlice())
+		assert.Equal(t, ef.Slice(1, 2), st.ToSlice())
+	})
+}
+
+func TestStreamSkip(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(4, 5),
+			Skip(OfVals(1, 2, 3, 4, 5), 3).ToSlice())
+	})
+
+	t.Run("SkipAll", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice[int](),
+			Skip(OfVals(1, 2), 3).ToSlice())
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := Skip(OfVals(1, 2, 3), 1)
+		assert.Equal(t, ef.Slice(2, 3), st.ToSlice())
+		assert.Equal(t, ef.Slice(2, 3), st.ToSlice())
+	})
+}
+
+func TestStreamTakeWhile(t *testing.T) {
+	readCount := 0
+	st := StreamPeek(OfVals(1, 2, 3, 4, 1), func(int) { readCount++ })
+	assert.Equal(t,
+		ef.Slice(1, 2),
+		TakeWhile(st, ef.Lesser(3)).ToSlice())
+	assert.Equal(t, 3, readCount)
+}
+
+func TestStreamDropWhile(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(3, 4, 1),
+			DropWhile(OfVals(1, 2, 3, 4, 1), ef.Lesser(3)).ToSlice())
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := DropWhile(OfVals(1, 2, 3, 1), ef.Lesser(2))
+		assert.Equal(t, ef.Slice(2, 3, 1), st.ToSlice())
+		assert.Equal(t, ef.Slice(2, 3, 1), st.ToSlice())
+	})
+}
+
+func TestStreamScan(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := Scan(OfVals(1, 2, 3), "", func(total string, v int) string {
+			return total + fmt.Sprint(v)
+		})
+		assert.Equal(t, ef.Slice("1", "12", "123"), st.ToSlice())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice[int](),
+			Scan(Empty[int](), 10, ef.Add[int]).ToSlice())
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := Scan(OfVals(1, 2, 3), 1, ef.Mult[int])
+		assert.Equal(t, ef.Slice(1, 2, 6), st.ToSlice())
+		assert.Equal(t, ef.Slice(1, 2, 6), st.ToSlice())
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		st := Scan(Repeat(2), 1, ef.Mult[int])
+		assert.Equal(t, ef.Slice(2, 4, 8), Limit(st, 3).ToSlice())
+	})
+}
+
+func TestStreamRunningSum(t *testing.T) {
+	assert.Equal(t,
+		ef.Slice(1, 3, 6, 5),
+		RunningSum(OfVals(1, 2, 3, -1)).ToSlice())
+	assert.Equal(t,
+		ef.Slice(0.5, 2.0),
+		RunningSum(OfVals(0.5, 1.5)).ToSlice())
+}
+
+func TestStreamRunningMin(t *testing.T) {
+	assert.Equal(t,
+		ef.Slice(3, 1, 1, -2),
+		RunningMin(OfVals(3, 1, 2, -2)).ToSlice())
+
+	type ID int
+	assert.Equal(t,
+		ef.Slice[ID](3, 1, 1),
+		RunningMin(OfVals[ID](3, 1, 2)).ToSlice())
+
+	st := RunningMin(OfVals(2, 1))
+	assert.Equal(t, ef.Slice(2, 1), st.ToSlice())
+	assert.Equal(t, ef.Slice(2, 1), st.ToSlice())
+}
+
+func TestStreamRunningMax(t *testing.T) {
+	assert.Equal(t,
+		ef.Slice(-3, -1, -1, 2),
+		RunningMax(OfVals(-3, -1, -2, 2)).ToSlice())
+	assert.Equal(t,
+		ef.Slice[uint8](0, 4, 4),
+		RunningMax(OfVals[uint8](0, 4, 1)).ToSlice())
+
+	type Score int64
+	assert.Equal(t,
+		ef.Slice[Score](1, 1, 3),
+		RunningMax(OfVals[Score](1, -2, 3)).ToSlice())
+}
+
+func TestStreamChunk(t *testing.T) {
+	t.Run("Even", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2), ef.Slice(3, 4)),
+			Chunk(OfVals(1, 2, 3, 4), 2).ToSlice())
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2), ef.Slice(3, 4), ef.Slice(5)),
+			Chunk(OfVals(1, 2, 3, 4, 5), 2).ToSlice())
+	})
+
+	t.Run("LargeSize", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2)),
+			Chunk(OfVals(1, 2), 1<<62).ToSlice())
+		chunks := Chunk(OfVals(1, 2), 1e7).ToSlice()
+		assert.Equal(t, ef.Slice(ef.Slice(1, 2)), chunks)
+		assert.LessOrEqual(t, cap(chunks[0]), maxPrealloc)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, ef.Slice[[]int](), Chunk(Empty[int](), 2).ToSlice())
+	})
+
+	t.Run("LimitedSource", func(t *testing.T) {
+		st := Limit(Iterate(1, func(v int) int { return v + 1 }), 5)
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2, 3), ef.Slice(4, 5)),
+			Chunk(st, 3).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		readCount := 0
+		st := StreamPeek(Repeat(1), func(int) { readCount++ })
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 1), ef.Slice(1, 1)),
+			Limit(Chunk(st, 2), 2).ToSlice())
+		assert.Equal(t, 4, readCount)
+	})
+
+	t.Run("BadSize", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Chunk(OfVals(1), 0)
+		})
+	})
+}
+
+func TestStreamWindow(t *testing.T) {
+	t.Run("Sliding", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2, 3), ef.Slice(2, 3, 4), ef.Slice(3, 4, 5)),
+			Window(OfVals(1, 2, 3, 4, 5), 3, 1).ToSlice())
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2, 3), ef.Slice(3, 4, 5)),
+			Window(OfVals(1, 2, 3, 4, 5, 6), 3, 2).ToSlice())
+	})
+
+	t.Run("LargeSize", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice[[]int](),
+			Window(OfVals(1, 2), 1<<62, 1).ToSlice())
+		assert.Equal(t,
+			ef.Slice[[]int](),
+			Window(OfVals(1, 2), 1e7, 1e7).ToSlice())
+	})
+
+	t.Run("Gaps", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 2), ef.Slice(4, 5)),
+			Window(OfVals(1, 2, 3, 4, 5, 6), 2, 3).ToSlice())
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice[[]int](),
+			Window(OfVals(1, 2), 3, 1).ToSlice())
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := Window(OfVals(1, 2, 3), 2, 1)
+		expected := ef.Slice(ef.Slice(1, 2), ef.Slice(2, 3))
+		assert.Equal(t, expected, st.ToSlice())
+		assert.Equal(t, expected, st.ToSlice())
+	})
+}
+
+func TestStreamChunkBy(t *testing.T) {
+	isOdd := func(v int) bool { return v%2 != 0 }
+
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 3), ef.Slice(2, 4), ef.Slice(5)),
+			ChunkBy(OfVals(1, 3, 2, 4, 5), isOdd).ToSlice())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice[[]int](),
+			ChunkBy(Empty[int](), isOdd).ToSlice())
+	})
+
+	t.Run("EarlyExit", func(t *testing.T) {
+		st := ChunkBy(OfVals(1, 3, 2, 4, 5), isOdd)
+		assert.Equal(t,
+			ef.Slice(ef.Slice(1, 3)),
+			Limit(st, 1).ToSlice())
+	})
+}
+
+func TestStreamDistinct(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(3, 1, 2),
+			Distinct(OfVals(3, 1, 3, 2, 1, 3)).ToSlice())
+	})
+
+	t.Run("Reiterate", func(t *testing.T) {
+		st := Distinct(OfVals(1, 1, 2))
+		assert.Equal(t, ef.Slice(1, 2), st.ToSlice())
+		assert.Equal(t, ef.Slice(1, 2), st.ToSlice())
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		st := Distinct(Cycle(OfVals(1, 2, 3)))
+		assert.Equal(t, ef.Slice(1, 2, 3), Limit(st, 3).ToSlice())
+	})
+}
+
+func TestStreamDistinctBy(t *testing.T) {
+	input := OfVals(ef.Slice(1), ef.Slice(2, 3), ef.Slice(4), ef.Slice[int]())
+	assert.Equal(t,
+		ef.Slice(ef.Slice(1), ef.Slice(2, 3), ef.Slice[int]()),
+		DistinctBy(input, func(v []int) int { return len(v) }).ToSlice())
+}
+
+func TestStreamDistinctAdjacent(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2, 1, 3),
+			DistinctAdjacent(OfVals(1, 1, 2, 2, 2, 1, 3, 3)).ToSlice())
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice("", "a"),
+			DistinctAdjacent(OfVals("", "", "a")).ToSlice())
+	})
+}
+
+func TestStreamSorted(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2, 3, 4, 5),
+			Sorted(OfVals(3, 1, 5, 2, 4)).ToSlice())
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice("a", "b", "c"),
+			Sorted(OfVals("c", "a", "b")).ToSlice())
+	})
+
+	t.Run("Lazy", func(t *testing.T) {
+		readCount := 0
+		st := Sorted(StreamPeek(OfVals(2, 1), func(int) { readCount++ }))
+		assert.Equal(t, 0, readCount)
+		assert.Equal(t, ef.Slice(1, 2), st.ToSlice())
+		assert.Equal(t, 2, readCount)
+	})
+}
+
+func TestStreamSortBy(t *testing.T) {
+	desc := func(a, b int) bool { return a > b }
+	assert.Equal(t,
+		ef.Slice(5, 4, 3, 2, 1),
+		SortBy(OfVals(3, 1, 5, 2, 4), desc).ToSlice())
+}
+
+func TestStreamSortStable(t *testing.T) {
+	input := OfVals(
+		ef.PairOf("b", 1),
+		ef.PairOf("a", 2),
+		ef.PairOf("b", 0),
+		ef.PairOf("a", 1),
+	)
+	byKey := func(a, b ef.Pair[string, int]) bool { return a.First < b.First }
+	assert.Equal(t,
+		ef.Slice(
+			ef.PairOf("a", 2),
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 1),
+			ef.PairOf("b", 0),
+		),
+		SortStable(input, byKey).ToSlice())
+}
+
+func TestStreamTopK(t *testing.T) {
+	asc := func(a, b int) bool { return a < b }
+	desc := func(a, b int) bool { return a > b }
+
+	t.Run("Smallest", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2, 3),
+			TopK(OfVals(5, 3, 8, 1, 9, 2), 3, asc).ToSlice())
+	})
+
+	t.Run("Largest", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(9, 8),
+			TopK(OfVals(5, 3, 8, 1, 9, 2), 2, desc).ToSlice())
+	})
+
+	t.Run("FewerThanK", func(t *testing.T) {
+		assert.Equal(t,
+			ef.Slice(1, 2),
+			TopK(OfVals(2, 1), 5, asc).ToSlice())
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		assert.Equal(t, ef.Slice[int](), TopK(OfVals(2, 1), 0, asc).ToSlice())
+	})
+
+	t.Run("Large", func(t *testing.T) {
+		st := Iterate(7, func(v int) int { return (v * 31) % 1009 })
+		assert.Equal(t,
+			Limit(Sorted(Limit(st, 1000)), 10).ToSlice(),
+			TopK(Limit(st, 1000), 10, asc).ToSlice())
+	})
+}
+
 func TestEach(t *testing.T) {
 
 	// todo [bs]: let's see if there are any interesting pstream options
@@ -22,6 +22,62 @@ func TestStream(t *testing.T) {
 		input := Slice("a", "b", "c")
 		assert.Equal(t, input, streamOfSlice(input).ToSlice())
 	})
+
+	t.Run("Seq", func(t *testing.T) {
+		input := Slice("a", "b", "c")
+		readValues := Slice[string]()
+		for v := range streamOfSlice(input).Seq() {
+			readValues = append(readValues, v)
+		}
+		assert.Equal(t, input, readValues)
+	})
+
+	t.Run("SeqBreak", func(t *testing.T) {
+		input := Slice("a", "b", "c")
+		readValues := Slice[string]()
+		for v := range streamOfSlice(input).Seq() {
+			readValues = append(readValues, v)
+			if v == "b" {
+				break
+			}
+		}
+		assert.Equal(t, Slice("a", "b"), readValues)
+	})
+
+	t.Run("SeqIgnoresAdvance", func(t *testing.T) {
+		st := NewStream[int](&FnIter[int]{
+			Fn: func(opFn func(int) bool) {
+				opFn(1)
+				opFn(2)
+				opFn(3)
+			},
+		})
+		readValues := Slice[int]()
+		assert.NotPanics(t, func() {
+			for v := range st.Seq() {
+				readValues = append(readValues, v)
+				break
+			}
+		})
+		assert.Equal(t, Slice(1), readValues)
+	})
+}
+
+func TestMultiStream(t *testing.T) {
+	t.Run("EarlyExit", func(t *testing.T) {
+		st := NewStream[string](&MultiStream[string]{
+			Streams: []Stream[string]{
+				streamOfSlice(Slice("a", "b")),
+				streamOfSlice(Slice("c", "d")),
+			},
+		})
+		readValues := Slice[string]()
+		st.ExitableEach(func(v string) bool {
+			readValues = append(readValues, v)
+			return v != "b"
+		})
+		assert.Equal(t, Slice("a", "b"), readValues)
+	})
 }
 
 func streamOfSlice[T any](values []T) Stream[T] {
@@ -5,6 +5,16 @@ type (
 		srcStream Stream[T]
 		transform func(T, func(U) bool) bool
 	}
+
+	streamTransformInit[T, U any] struct {
+		srcStream Stream[T]
+		initFn    func() func(T, func(U) bool) bool
+	}
+
+	streamTransformFlush[T, U any] struct {
+		srcStream Stream[T]
+		initFn    func() (func(T, func(U) bool) bool, func(func(U) bool))
+	}
 )
 
 func (s *streamTransform[T, U]) Next(opFn func(U) bool) {
@@ -13,6 +23,31 @@ func (s *streamTransform[T, U]) Next(opFn func(U) bool) {
 	})
 }
 
+func (s *streamTransformInit[T, U]) Next(opFn func(U) bool) {
+	transform := s.initFn()
+	s.srcStream.srcIter.Next(func(val T) bool {
+		return transform(val, opFn)
+	})
+}
+
+func (s *streamTransformFlush[T, U]) Next(opFn func(U) bool) {
+	transform, flush := s.initFn()
+	stopped := false
+	nextOp := func(val U) bool {
+		if !opFn(val) {
+			stopped = true
+			return false
+		}
+		return true
+	}
+	s.srcStream.srcIter.Next(func(val T) bool {
+		return transform(val, nextOp)
+	})
+	if !stopped {
+		flush(nextOp)
+	}
+}
+
 // StreamTransform is a generic helper that can be used to inject an operator in
 // a stream, and allow for composition.
 func StreamTransform[T, U any](
@@ -26,3 +61,40 @@ func StreamTransform[T, U any](
 		},
 	}
 }
+
+// StreamTransformInit is as StreamTransform, but for operators that need state
+// over the course of an iteration (e.g. a count of values seen so far).
+// `initFn` is called at the start of every iteration of the stream to create a
+// fresh operator, so the state isn't shared if the stream is iterated more than
+// once.
+func StreamTransformInit[T, U any](
+	srcSt Stream[T],
+	initFn func() func(val T, nextOp func(U) bool) (advance bool),
+) Stream[U] {
+	return Stream[U]{
+		srcIter: &streamTransformInit[T, U]{
+			srcStream: srcSt,
+			initFn:    initFn,
+		},
+	}
+}
+
+// StreamTransformFlush is as StreamTransformInit, but for operators that buffer
+// values (e.g. grouping them into chunks). Along with the operator, `initFn`
+// returns a flush function that's called once the source stream ends, so any
+// values still buffered can be passed on. Flush is not called if iteration was
+// stopped by a later operator.
+func StreamTransformFlush[T, U any](
+	srcSt Stream[T],
+	initFn func() (
+		op func(val T, nextOp func(U) bool) (advance bool),
+		flushOp func(nextOp func(U) bool),
+	),
+) Stream[U] {
+	return Stream[U]{
+		srcIter: &streamTransformFlush[T, U]{
+			srcStream: srcSt,
+			initFn:    initFn,
+		},
+	}
+}
@@ -2,8 +2,74 @@ package ef
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestStreamTransform(t *testing.T) {
-	// todo [bs]: add some tests
+	st := StreamTransform(
+		streamOfSlice(Slice(1, 2, 3)),
+		func(val int, nextOp func(int) bool) bool {
+			return nextOp(val * 2)
+		})
+	assert.Equal(t, Slice(2, 4, 6), st.ToSlice())
+}
+
+func TestStreamTransformInit(t *testing.T) {
+	st := StreamTransformInit(
+		streamOfSlice(Slice("a", "b", "c")),
+		func() func(string, func(Pair[int, string]) bool) bool {
+			index := 0
+			return func(val string, nextOp func(Pair[int, string]) bool) bool {
+				index++
+				return nextOp(PairOf(index, val))
+			}
+		})
+	expected := Slice(PairOf(1, "a"), PairOf(2, "b"), PairOf(3, "c"))
+
+	// iterating twice checks that state is reset between iterations.
+	assert.Equal(t, expected, st.ToSlice())
+	assert.Equal(t, expected, st.ToSlice())
+}
+
+func TestStreamTransformFlush(t *testing.T) {
+	pairUp := func(srcSt Stream[int]) Stream[[]int] {
+		return StreamTransformFlush(srcSt, func() (
+			func(int, func([]int) bool) bool,
+			func(func([]int) bool),
+		) {
+			var buf []int
+			op := func(val int, nextOp func([]int) bool) bool {
+				buf = append(buf, val)
+				if len(buf) < 2 {
+					return true
+				}
+				full := buf
+				buf = nil
+				return nextOp(full)
+			}
+			flush := func(nextOp func([]int) bool) {
+				if len(buf) > 0 {
+					nextOp(buf)
+				}
+			}
+			return op, flush
+		})
+	}
+
+	t.Run("Flush", func(t *testing.T) {
+		st := pairUp(streamOfSlice(Slice(1, 2, 3)))
+		assert.Equal(t, Slice(Slice(1, 2), Slice(3)), st.ToSlice())
+		assert.Equal(t, Slice(Slice(1, 2), Slice(3)), st.ToSlice())
+	})
+
+	t.Run("NoFlushAfterStop", func(t *testing.T) {
+		st := pairUp(streamOfSlice(Slice(1, 2, 3)))
+		readVals := Slice[[]int]()
+		st.ExitableEach(func(v []int) bool {
+			readVals = append(readVals, v)
+			return false
+		})
+		assert.Equal(t, Slice(Slice(1, 2)), readVals)
+	})
 }
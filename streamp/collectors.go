@@ -1,7 +1,12 @@
 package streamp
 
 import (
+	"iter"
+
 	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/bimap"
+	"github.com/BennettJames/ef/collect"
+	"github.com/BennettJames/ef/multimap"
 	"github.com/BennettJames/ef/stream"
 )
 
@@ -85,3 +90,82 @@ func AllMatch[T, U any](
 		return matchOp(p.Get())
 	})
 }
+
+// GroupByKey gathers a pair-stream into a map from each key to all the values
+// paired with it, in stream order.
+//
+// Example:
+//
+//	st := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 3))
+//	streamp.GroupByKey(st) // == map[string][]int{"a": {1, 3}, "b": {2}}
+func GroupByKey[K comparable, V any](srcSt ef.Stream[ef.Pair[K, V]]) map[K][]V {
+	return stream.GroupByCollect(
+		srcSt,
+		func(p ef.Pair[K, V]) K { return p.First },
+		collect.Mapping(func(p ef.Pair[K, V]) V { return p.Second }, collect.ToSlice[V]()))
+}
+
+// ToMultiMap gathers a pair-stream into a multimap, keeping every value for
+// each key in stream order.
+func ToMultiMap[K, V comparable](srcSt ef.Stream[ef.Pair[K, V]]) *multimap.MultiMap[K, V] {
+	return stream.Collect(srcSt, multimap.Collect[K, V]())
+}
+
+// ToBiMap gathers a pair-stream into a bimap. Every key and value must be
+// unique, so the first conflicting pair stops the stream and returns an error
+// result: a `*ef.DuplicateKeyError[K, V]` if the key already has a different
+// value, or a `*bimap.ConflictError[K, V]` if the value already belongs to a
+// different key. A pair repeated exactly is not a conflict.
+func ToBiMap[K, V comparable](srcSt ef.Stream[ef.Pair[K, V]]) ef.Res[*bimap.BiMap[K, V]] {
+	m := bimap.New[K, V]()
+	var conflictErr error
+	srcSt.ExitableEach(func(p ef.Pair[K, V]) bool {
+		key, val := p.Get()
+		if existing := m.GetByKey(key); existing.HasVal() && existing.UnsafeGet() != val {
+			conflictErr = &ef.DuplicateKeyError[K, V]{
+				Key:       key,
+				Existing:  existing.UnsafeGet(),
+				Duplicate: val,
+			}
+			return false
+		}
+		if err := m.Put(key, val); err != nil {
+			conflictErr = err
+			return false
+		}
+		return true
+	})
+	if conflictErr != nil {
+		return ef.NewResError[*bimap.BiMap[K, V]](conflictErr)
+	}
+	return ef.NewResValue(m)
+}
+
+// Unzip splits a pair-stream into two slices, the first holding the first value
+// of each pair and the second the second.
+func Unzip[T, U any](srcSt ef.Stream[ef.Pair[T, U]]) ([]T, []U) {
+	firsts, seconds := make([]T, 0), make([]U, 0)
+	EachPair(srcSt, func(t T, u U) {
+		firsts = append(firsts, t)
+		seconds = append(seconds, u)
+	})
+	return firsts, seconds
+}
+
+// Seq2 converts the pair-stream to a standard library key/value iterator, so it
+// can be used directly in a two-value `for range` loop.
+//
+// Example:
+//
+//	for k, v := range streamp.Seq2(stream.OfMap(m)) {
+//	  fmt.Println("key is - ", k, "value is - ", v)
+//	}
+func Seq2[K, V any](srcSt ef.Stream[ef.Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range srcSt.Seq() {
+			if !yield(p.Get()) {
+				return
+			}
+		}
+	}
+}
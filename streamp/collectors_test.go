@@ -1,9 +1,11 @@
 package streamp
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/BennettJames/ef"
+	"github.com/BennettJames/ef/bimap"
 	"github.com/BennettJames/ef/stream"
 	"github.com/stretchr/testify/assert"
 )
@@ -113,6 +115,113 @@ func TestPStreamAllMatch(t *testing.T) {
 	assert.True(t, foundVal)
 }
 
+func TestPStreamGroupByKey(t *testing.T) {
+	input := stream.OfVals(
+		ef.PairOf("a", 1),
+		ef.PairOf("b", 2),
+		ef.PairOf("a", 3),
+	)
+	assert.Equal(t,
+		map[string][]int{
+			"a": ef.Slice(1, 3),
+			"b": ef.Slice(2),
+		},
+		GroupByKey(input))
+}
+
+func TestPStreamToMultiMap(t *testing.T) {
+	st := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 3))
+	m := ToMultiMap(st)
+	assert.Equal(t, ef.Slice(1, 3), m.Get("a"))
+	assert.Equal(t, ef.Slice(2), m.Get("b"))
+	assert.Equal(t, 3, m.Len())
+}
+
+func TestPStreamToBiMap(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 1))
+		m := ToBiMap(st).Val()
+		assert.Equal(t, 2, m.Len())
+		assert.Equal(t, ef.NewOptValue("b"), m.GetByValue(2))
+	})
+
+	t.Run("DuplicateKey", func(t *testing.T) {
+		st := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("a", 2))
+		res := ToBiMap(st)
+		assert.Equal(t, &ef.DuplicateKeyError[string, int]{
+			Key:       "a",
+			Existing:  1,
+			Duplicate: 2,
+		}, res.Err())
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		readCount := 0
+		st := stream.StreamPeek(stream.OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 1),
+			ef.PairOf("c", 1),
+		), func(ef.Pair[string, int]) { readCount++ })
+		res := ToBiMap(st)
+		assert.Equal(t, 2, readCount)
+
+		var conflictErr *bimap.ConflictError[string, int]
+		assert.True(t, errors.As(res.Err(), &conflictErr))
+		assert.Equal(t, &bimap.ConflictError[string, int]{
+			Key:         "b",
+			Value:       1,
+			ExistingKey: "a",
+		}, conflictErr)
+	})
+}
+
+func TestPStreamUnzip(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		firsts, seconds := Unzip(stream.Zip(
+			stream.OfVals(1, 2, 3),
+			stream.OfVals("a", "b", "c"),
+		))
+		assert.Equal(t, ef.Slice(1, 2, 3), firsts)
+		assert.Equal(t, ef.Slice("a", "b", "c"), seconds)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		firsts, seconds := Unzip(stream.Empty[ef.Pair[int, string]]())
+		assert.Equal(t, ef.Slice[int](), firsts)
+		assert.Equal(t, ef.Slice[string](), seconds)
+	})
+}
+
+func TestPStreamSeq2(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		st := stream.OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 2),
+		)
+		m := map[string]int{}
+		for k, v := range Seq2(st) {
+			m[k] = v
+		}
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		st := stream.OfVals(
+			ef.PairOf("a", 1),
+			ef.PairOf("b", 2),
+			ef.PairOf("c", 3),
+		)
+		keys := ef.Slice[string]()
+		for k := range Seq2(st) {
+			keys = append(keys, k)
+			if k == "b" {
+				break
+			}
+		}
+		assert.Equal(t, ef.Slice("a", "b"), keys)
+	})
+}
+
 func addToMultiMap[K comparable, V any](m map[K][]V, key K, val V) map[K][]V {
 	// this is interesting. I think this would be a good addition, but not
 	// sure now is the time?
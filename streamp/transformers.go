@@ -1,6 +1,8 @@
 package streamp
 
 import (
+	"cmp"
+
 	"github.com/BennettJames/ef"
 	"github.com/BennettJames/ef/stream"
 )
@@ -74,6 +76,83 @@ func Remove[T, U any](
 	})
 }
 
+// Limit returns a stream consisting of at most the first `n` pairs of the
+// source stream.
+func Limit[T, U any](
+	srcSt ef.Stream[ef.Pair[T, U]],
+	n int,
+) ef.Stream[ef.Pair[T, U]] {
+	return stream.Limit(srcSt, n)
+}
+
+// Skip returns a stream consisting of the pairs of the source stream after the
+// first `n` pairs have been discarded.
+func Skip[T, U any](
+	srcSt ef.Stream[ef.Pair[T, U]],
+	n int,
+) ef.Stream[ef.Pair[T, U]] {
+	return stream.Skip(srcSt, n)
+}
+
+// TakeWhile returns a stream consisting of the pairs of the source stream up
+// until the first one that does not pass the given check.
+func TakeWhile[T, U any](
+	srcSt ef.Stream[ef.Pair[T, U]],
+	takeOp func(T, U) bool,
+) ef.Stream[ef.Pair[T, U]] {
+	return stream.TakeWhile(srcSt, func(p ef.Pair[T, U]) bool {
+		return takeOp(p.Get())
+	})
+}
+
+// DropWhile returns a stream that discards pairs of the source stream until one
+// fails the given check, then consists of that pair and all that follow it.
+func DropWhile[T, U any](
+	srcSt ef.Stream[ef.Pair[T, U]],
+	dropOp func(T, U) bool,
+) ef.Stream[ef.Pair[T, U]] {
+	return stream.DropWhile(srcSt, func(p ef.Pair[T, U]) bool {
+		return dropOp(p.Get())
+	})
+}
+
+// DistinctKeys returns a stream of the pairs of the source stream, removing any
+// pair whose key was already seen in an earlier pair.
+func DistinctKeys[K comparable, V any](
+	srcSt ef.Stream[ef.Pair[K, V]],
+) ef.Stream[ef.Pair[K, V]] {
+	return stream.DistinctBy(srcSt, func(p ef.Pair[K, V]) K {
+		return p.First
+	})
+}
+
+// SortByKey returns a stream of the pairs of the source stream, sorted in
+// ascending order of their keys. Pairs with equal keys keep their original
+// order.
+//
+// This is mostly useful to give a deterministic order to streams made from
+// maps, e.g. -
+//
+//	st := SortByKey(stream.OfMap(m))
+func SortByKey[K cmp.Ordered, V any](
+	srcSt ef.Stream[ef.Pair[K, V]],
+) ef.Stream[ef.Pair[K, V]] {
+	return stream.SortStable(srcSt, func(a, b ef.Pair[K, V]) bool {
+		return a.First < b.First
+	})
+}
+
+// SortByValue returns a stream of the pairs of the source stream, sorted in
+// ascending order of their values. Pairs with equal values keep their original
+// order.
+func SortByValue[K any, V cmp.Ordered](
+	srcSt ef.Stream[ef.Pair[K, V]],
+) ef.Stream[ef.Pair[K, V]] {
+	return stream.SortStable(srcSt, func(a, b ef.Pair[K, V]) bool {
+		return a.Second < b.Second
+	})
+}
+
 // EachPair will perform the given function on each element of the input pair
 // stream.
 func EachPair[T, U any, S ef.Streamable[ef.Pair[T, U]]](srcSt S, eachOp func(T, U)) {
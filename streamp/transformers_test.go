@@ -90,3 +90,56 @@ func TestPStreamRemove(t *testing.T) {
 	filtered := Remove(input, match).ToSlice()
 	assert.Equal(t, ef.Slice(ef.PairOf(2, 3)), filtered)
 }
+
+func TestPStreamLimit(t *testing.T) {
+	input := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("c", 3))
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 1), ef.PairOf("b", 2)),
+		Limit(input, 2).ToSlice())
+}
+
+func TestPStreamSkip(t *testing.T) {
+	input := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("c", 3))
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("c", 3)),
+		Skip(input, 2).ToSlice())
+}
+
+func TestPStreamTakeWhile(t *testing.T) {
+	input := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("c", 1))
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 1)),
+		TakeWhile(input, func(k string, v int) bool {
+			return v < 2
+		}).ToSlice())
+}
+
+func TestPStreamDropWhile(t *testing.T) {
+	input := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("c", 1))
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("b", 2), ef.PairOf("c", 1)),
+		DropWhile(input, func(k string, v int) bool {
+			return v < 2
+		}).ToSlice())
+}
+
+func TestPStreamSortByKey(t *testing.T) {
+	input := stream.OfMap(map[string]int{"b": 1, "c": 0, "a": 2})
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 2), ef.PairOf("b", 1), ef.PairOf("c", 0)),
+		SortByKey(input).ToSlice())
+}
+
+func TestPStreamSortByValue(t *testing.T) {
+	input := stream.OfMap(map[string]int{"b": 1, "c": 0, "a": 2})
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("c", 0), ef.PairOf("b", 1), ef.PairOf("a", 2)),
+		SortByValue(input).ToSlice())
+}
+
+func TestPStreamDistinctKeys(t *testing.T) {
+	input := stream.OfVals(ef.PairOf("a", 1), ef.PairOf("b", 2), ef.PairOf("a", 3))
+	assert.Equal(t,
+		ef.Slice(ef.PairOf("a", 1), ef.PairOf("b", 2)),
+		DistinctKeys(input).ToSlice())
+}
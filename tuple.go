@@ -0,0 +1,129 @@
+package ef
+
+import "fmt"
+
+type (
+	// Triple is a combination of three values of any type.
+	Triple[T1, T2, T3 any] struct {
+		First  T1
+		Second T2
+		Third  T3
+	}
+
+	// Quad is a combination of four values of any type.
+	Quad[T1, T2, T3, T4 any] struct {
+		First  T1
+		Second T2
+		Third  T3
+		Fourth T4
+	}
+)
+
+// TripleOf creates a triple of three different values.
+func TripleOf[T1, T2, T3 any](
+	first T1,
+	second T2,
+	third T3,
+) Triple[T1, T2, T3] {
+	return Triple[T1, T2, T3]{
+		First:  first,
+		Second: second,
+		Third:  third,
+	}
+}
+
+// QuadOf creates a quad of four different values.
+func QuadOf[T1, T2, T3, T4 any](
+	first T1,
+	second T2,
+	third T3,
+	fourth T4,
+) Quad[T1, T2, T3, T4] {
+	return Quad[T1, T2, T3, T4]{
+		First:  first,
+		Second: second,
+		Third:  third,
+		Fourth: fourth,
+	}
+}
+
+// Get unpacks the three values in the triple.
+func (t Triple[T1, T2, T3]) Get() (T1, T2, T3) {
+	return t.First, t.Second, t.Third
+}
+
+func (t Triple[T1, T2, T3]) String() string {
+	return fmt.Sprintf("(`%v`, `%v`, `%v`)", t.First, t.Second, t.Third)
+}
+
+// Reverse returns a triple of the same values in the opposite order.
+func (t Triple[T1, T2, T3]) Reverse() Triple[T3, T2, T1] {
+	return TripleOf(t.Third, t.Second, t.First)
+}
+
+// DropFirst returns a pair of the last two values in the triple.
+func (t Triple[T1, T2, T3]) DropFirst() Pair[T2, T3] {
+	return PairOf(t.Second, t.Third)
+}
+
+// DropLast returns a pair of the first two values in the triple.
+func (t Triple[T1, T2, T3]) DropLast() Pair[T1, T2] {
+	return PairOf(t.First, t.Second)
+}
+
+// Get unpacks the four values in the quad.
+func (q Quad[T1, T2, T3, T4]) Get() (T1, T2, T3, T4) {
+	return q.First, q.Second, q.Third, q.Fourth
+}
+
+func (q Quad[T1, T2, T3, T4]) String() string {
+	return fmt.Sprintf("(`%v`, `%v`, `%v`, `%v`)", q.First, q.Second, q.Third, q.Fourth)
+}
+
+// Reverse returns a quad of the same values in the opposite order.
+func (q Quad[T1, T2, T3, T4]) Reverse() Quad[T4, T3, T2, T1] {
+	return QuadOf(q.Fourth, q.Third, q.Second, q.First)
+}
+
+// DropFirst returns a triple of the last three values in the quad.
+func (q Quad[T1, T2, T3, T4]) DropFirst() Triple[T2, T3, T4] {
+	return TripleOf(q.Second, q.Third, q.Fourth)
+}
+
+// DropLast returns a triple of the first three values in the quad.
+func (q Quad[T1, T2, T3, T4]) DropLast() Triple[T1, T2, T3] {
+	return TripleOf(q.First, q.Second, q.Third)
+}
+
+// PairAppend creates a triple from the values of the pair, followed by `third`.
+func PairAppend[T1, T2, T3 any](p Pair[T1, T2], third T3) Triple[T1, T2, T3] {
+	return TripleOf(p.First, p.Second, third)
+}
+
+// PairPrepend creates a triple from `first`, followed by the values of the
+// pair.
+func PairPrepend[T1, T2, T3 any](first T1, p Pair[T2, T3]) Triple[T1, T2, T3] {
+	return TripleOf(first, p.First, p.Second)
+}
+
+// TripleAppend creates a quad from the values of the triple, followed by
+// `fourth`.
+func TripleAppend[T1, T2, T3, T4 any](t Triple[T1, T2, T3], fourth T4) Quad[T1, T2, T3, T4] {
+	return QuadOf(t.First, t.Second, t.Third, fourth)
+}
+
+// TriplePrepend creates a quad from `first`, followed by the values of the
+// triple.
+func TriplePrepend[T1, T2, T3, T4 any](first T1, t Triple[T2, T3, T4]) Quad[T1, T2, T3, T4] {
+	return QuadOf(first, t.First, t.Second, t.Third)
+}
+
+// PairMapFirst transforms the first value of the pair, and keeps the second.
+func PairMapFirst[T1, T2, U any](p Pair[T1, T2], mapOp func(T1) U) Pair[U, T2] {
+	return PairOf(mapOp(p.First), p.Second)
+}
+
+// PairMapSecond transforms the second value of the pair, and keeps the first.
+func PairMapSecond[T1, T2, U any](p Pair[T1, T2], mapOp func(T2) U) Pair[T1, U] {
+	return PairOf(p.First, mapOp(p.Second))
+}
@@ -0,0 +1,102 @@
+package ef
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriple(t *testing.T) {
+
+	t.Run("Of", func(t *testing.T) {
+		assert.Equal(t,
+			Triple[string, int, bool]{
+				First:  "hello",
+				Second: 22,
+				Third:  true,
+			},
+			TripleOf("hello", 22, true))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		v1, v2, v3 := TripleOf("hello", 22, true).Get()
+		assert.Equal(t, "hello", v1)
+		assert.Equal(t, 22, v2)
+		assert.Equal(t, true, v3)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "(`a`, `b`, `c`)", TripleOf("a", "b", "c").String())
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		assert.Equal(t, TripleOf(true, 22, "hello"), TripleOf("hello", 22, true).Reverse())
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		tr := TripleOf("hello", 22, true)
+		assert.Equal(t, PairOf(22, true), tr.DropFirst())
+		assert.Equal(t, PairOf("hello", 22), tr.DropLast())
+	})
+}
+
+func TestQuad(t *testing.T) {
+
+	t.Run("Of", func(t *testing.T) {
+		assert.Equal(t,
+			Quad[string, int, bool, float64]{
+				First:  "hello",
+				Second: 22,
+				Third:  true,
+				Fourth: 1.5,
+			},
+			QuadOf("hello", 22, true, 1.5))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		v1, v2, v3, v4 := QuadOf("hello", 22, true, 1.5).Get()
+		assert.Equal(t, "hello", v1)
+		assert.Equal(t, 22, v2)
+		assert.Equal(t, true, v3)
+		assert.Equal(t, 1.5, v4)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "(`a`, `b`, `c`, `1`)", QuadOf("a", "b", "c", 1).String())
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		assert.Equal(t,
+			QuadOf(1.5, true, 22, "hello"),
+			QuadOf("hello", 22, true, 1.5).Reverse())
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		q := QuadOf("hello", 22, true, 1.5)
+		assert.Equal(t, TripleOf(22, true, 1.5), q.DropFirst())
+		assert.Equal(t, TripleOf("hello", 22, true), q.DropLast())
+	})
+}
+
+func TestTupleConversions(t *testing.T) {
+
+	t.Run("PairAppend", func(t *testing.T) {
+		assert.Equal(t, TripleOf("a", 1, true), PairAppend(PairOf("a", 1), true))
+		assert.Equal(t, TripleOf(true, "a", 1), PairPrepend(true, PairOf("a", 1)))
+	})
+
+	t.Run("TripleAppend", func(t *testing.T) {
+		tr := TripleOf("a", 1, true)
+		assert.Equal(t, QuadOf("a", 1, true, 1.5), TripleAppend(tr, 1.5))
+		assert.Equal(t, QuadOf(1.5, "a", 1, true), TriplePrepend(1.5, tr))
+	})
+
+	t.Run("PairMap", func(t *testing.T) {
+		p := PairOf(1, "2")
+		assert.Equal(t, PairOf("1", "2"), PairMapFirst(p, strconv.Itoa))
+		assert.Equal(t, PairOf(1, 2), PairMapSecond(p, func(s string) int {
+			return Try(strconv.Atoi(s))
+		}))
+	})
+}